@@ -3,9 +3,12 @@ package runner
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"net"
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
@@ -16,15 +19,71 @@ import (
 
 // loadProxyServers load list of proxy servers from file or comma separated
 func loadProxyServers(options *types.Options) error {
-	if len(options.Proxy) == 0 {
+	if len(options.Proxy) == 0 && len(options.ProxyHTTP) == 0 && len(options.ProxyNetwork) == 0 {
 		return nil
 	}
+	types.ProxyURL, types.ProxySocksURL, types.NetworkProxyURL = "", "", ""
+
+	// proxy is used for all traffic unless overridden by
+	// traffic specific proxies (-proxy-http / -proxy-network)
+	if len(options.Proxy) > 0 {
+		proxyURL, err := getAliveProxy(options, options.Proxy)
+		if err != nil {
+			return err
+		}
+		setHTTPProxy(proxyURL)
+		types.NetworkProxyURL = proxyURL.String()
+	}
+	if len(options.ProxyHTTP) > 0 {
+		proxyURL, err := getAliveProxy(options, options.ProxyHTTP)
+		if err != nil {
+			return err
+		}
+		setHTTPProxy(proxyURL)
+	}
+	if len(options.ProxyNetwork) > 0 {
+		proxyURL, err := getAliveProxy(options, options.ProxyNetwork)
+		if err != nil {
+			return err
+		}
+		if proxyURL.Scheme != proxyutils.SOCKS5 {
+			return errorutil.New("only socks5 proxies are supported for non-http traffic got %v", proxyURL.Redacted())
+		}
+		types.NetworkProxyURL = proxyURL.String()
+		gologger.Verbose().Msgf("Using %s as socket proxy server for non-http traffic", proxyURL.Redacted())
+	}
+	if options.ProxyInternal {
+		if types.ProxyURL != "" {
+			os.Setenv(types.HTTP_PROXY_ENV, types.ProxyURL)
+		} else if types.ProxySocksURL != "" {
+			os.Setenv(types.HTTP_PROXY_ENV, types.ProxySocksURL)
+		}
+	}
+	return nil
+}
+
+// setHTTPProxy sets the proxy used for http traffic
+func setHTTPProxy(proxyURL *url.URL) {
+	if proxyURL.Scheme == proxyutils.HTTP || proxyURL.Scheme == proxyutils.HTTPS {
+		types.ProxyURL = proxyURL.String()
+		types.ProxySocksURL = ""
+		gologger.Verbose().Msgf("Using %s as proxy server", proxyURL.Redacted())
+	} else if proxyURL.Scheme == proxyutils.SOCKS5 {
+		types.ProxyURL = ""
+		types.ProxySocksURL = proxyURL.String()
+		gologger.Verbose().Msgf("Using %s as socket proxy server", proxyURL.Redacted())
+	}
+}
+
+// getAliveProxy returns first alive proxy from list of proxies (or files containing proxies)
+// socks5 proxies with credentials are only considered alive if credentials are accepted
+func getAliveProxy(options *types.Options, proxies []string) (*url.URL, error) {
 	proxyList := []string{}
-	for _, p := range options.Proxy {
+	for _, p := range proxies {
 		if fileutil.FileExists(p) {
 			file, err := os.Open(p)
 			if err != nil {
-				return fmt.Errorf("could not open proxy file: %w", err)
+				return nil, fmt.Errorf("could not open proxy file: %w", err)
 			}
 			defer file.Close()
 			scanner := bufio.NewScanner(file)
@@ -41,23 +100,61 @@ func loadProxyServers(options *types.Options) error {
 	}
 	aliveProxy, err := proxyutils.GetAnyAliveProxy(options.Timeout, proxyList...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	proxyURL, err := url.Parse(aliveProxy)
 	if err != nil {
-		return errorutil.WrapfWithNil(err, "failed to parse proxy got %v", err)
+		return nil, errorutil.WrapfWithNil(err, "failed to parse proxy got %v", err)
 	}
-	if options.ProxyInternal {
-		os.Setenv(types.HTTP_PROXY_ENV, proxyURL.String())
+	if proxyURL.Scheme == proxyutils.SOCKS5 && proxyURL.User != nil {
+		if err := verifySocks5Auth(proxyURL, options.Timeout); err != nil {
+			return nil, errorutil.NewWithErr(err).Msgf("could not authenticate to socks5 proxy %v", proxyURL.Redacted())
+		}
 	}
-	if proxyURL.Scheme == proxyutils.HTTP || proxyURL.Scheme == proxyutils.HTTPS {
-		types.ProxyURL = proxyURL.String()
-		types.ProxySocksURL = ""
-		gologger.Verbose().Msgf("Using %s as proxy server", proxyURL.String())
-	} else if proxyURL.Scheme == proxyutils.SOCKS5 {
-		types.ProxyURL = ""
-		types.ProxySocksURL = proxyURL.String()
-		gologger.Verbose().Msgf("Using %s as socket proxy server", proxyURL.String())
+	return proxyURL, nil
+}
+
+// verifySocks5Auth verifies that socks5 proxy accepts username/password
+// credentials of proxy url (RFC 1928 / RFC 1929)
+func verifySocks5Auth(proxyURL *url.URL, timeoutInSec int) error {
+	username := proxyURL.User.Username()
+	password, _ := proxyURL.User.Password()
+	if len(username) == 0 || len(username) > 255 || len(password) > 255 {
+		return errorutil.New("invalid socks5 username or password length")
+	}
+
+	timeout := time.Duration(timeoutInSec) * time.Second
+	conn, err := net.DialTimeout("tcp", proxyURL.Host, timeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	// version 5, one method: username/password
+	if _, err := conn.Write([]byte{0x05, 0x01, 0x02}); err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[0] != 0x05 || reply[1] != 0x02 {
+		return errorutil.New("proxy does not support username/password authentication")
+	}
+
+	request := []byte{0x01, byte(len(username))}
+	request = append(request, username...)
+	request = append(request, byte(len(password)))
+	request = append(request, password...)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return err
+	}
+	if reply[1] != 0x00 {
+		return errorutil.New("invalid username or password")
 	}
 	return nil
 }
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSocks5Server starts a socks5 server which only performs username/password authentication
+func newTestSocks5Server(t *testing.T, username, password string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				greeting := make([]byte, 3)
+				if _, err := io.ReadFull(conn, greeting); err != nil {
+					return
+				}
+				_, _ = conn.Write([]byte{0x05, 0x02})
+
+				header := make([]byte, 2)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				user := make([]byte, header[1])
+				_, _ = io.ReadFull(conn, user)
+				passLen := make([]byte, 1)
+				_, _ = io.ReadFull(conn, passLen)
+				pass := make([]byte, passLen[0])
+				_, _ = io.ReadFull(conn, pass)
+
+				status := byte(0x01)
+				if string(user) == username && string(pass) == password {
+					status = 0x00
+				}
+				_, _ = conn.Write([]byte{0x01, status})
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestVerifySocks5Auth(t *testing.T) {
+	addr := newTestSocks5Server(t, "user", "pass")
+
+	valid, _ := url.Parse("socks5://user:pass@" + addr)
+	require.Nil(t, verifySocks5Auth(valid, 5), "could not authenticate with valid credentials")
+
+	invalid, _ := url.Parse("socks5://user:wrong@" + addr)
+	require.NotNil(t, verifySocks5Auth(invalid, 5), "authenticated with invalid credentials")
+}
+
+func TestLoadProxyServers(t *testing.T) {
+	defer func() {
+		types.ProxyURL, types.ProxySocksURL, types.NetworkProxyURL = "", "", ""
+	}()
+	addr := newTestSocks5Server(t, "user", "pass")
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	defer httpListener.Close()
+
+	t.Run("separate-proxies", func(t *testing.T) {
+		options := &types.Options{
+			Timeout:      5,
+			ProxyHTTP:    []string{"http://" + httpListener.Addr().String()},
+			ProxyNetwork: []string{"socks5://user:pass@" + addr},
+		}
+		require.Nil(t, loadProxyServers(options))
+		require.Equal(t, "http://"+httpListener.Addr().String(), types.ProxyURL)
+		require.Empty(t, types.ProxySocksURL)
+		require.Equal(t, "socks5://user:pass@"+addr, types.NetworkProxyURL)
+	})
+
+	t.Run("proxy-internal", func(t *testing.T) {
+		t.Setenv(types.HTTP_PROXY_ENV, "")
+		options := &types.Options{
+			Timeout:       5,
+			ProxyInternal: true,
+			ProxyHTTP:     []string{"http://" + httpListener.Addr().String()},
+			ProxyNetwork:  []string{"socks5://user:pass@" + addr},
+		}
+		require.Nil(t, loadProxyServers(options))
+		require.Equal(t, "http://"+httpListener.Addr().String(), os.Getenv(types.HTTP_PROXY_ENV), "could not set http proxy for internal requests")
+	})
+
+	t.Run("invalid-credentials", func(t *testing.T) {
+		options := &types.Options{Timeout: 5, Proxy: []string{"socks5://user:wrong@" + addr}}
+		require.NotNil(t, loadProxyServers(options))
+	})
+
+	t.Run("non-socks-network-proxy", func(t *testing.T) {
+		options := &types.Options{Timeout: 5, ProxyNetwork: []string{"http://" + httpListener.Addr().String()}}
+		require.NotNil(t, loadProxyServers(options))
+	})
+}
@@ -28,12 +28,14 @@ import (
 
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/internal/colorizer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/disk"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/loader"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core/inputs/hybrid"
+	"github.com/projectdiscovery/nuclei/v3/pkg/evidence"
 	"github.com/projectdiscovery/nuclei/v3/pkg/external/customtemplates"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
@@ -43,6 +45,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/automaticscan"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/executionstats"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/hosterrorscache"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolinit"
@@ -51,6 +54,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/headless/engine"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
+	"github.com/projectdiscovery/nuclei/v3/pkg/suppression"
 	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils"
@@ -81,6 +85,8 @@ type Runner struct {
 	browser           *engine.Browser
 	rateLimiter       *ratelimit.Limiter
 	hostErrors        hosterrorscache.CacheInterface
+	suppressor        *suppression.Suppressor
+	evidence          *evidence.Bundle
 	resumeCfg         *types.ResumeCfg
 	pprofServer       *http.Server
 	// pdcp auto-save options
@@ -195,6 +201,14 @@ func New(options *types.Options) (*Runner, error) {
 		runner.issuesClient = client
 	}
 
+	if len(options.SuppressionFile) > 0 {
+		suppressor, err := suppression.New(options.SuppressionFile...)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create suppressor")
+		}
+		runner.suppressor = suppressor
+	}
+
 	// output coloring
 	useColor := !options.NoColor
 	runner.colorizer = aurora.NewAurora(useColor)
@@ -234,6 +248,27 @@ func New(options *types.Options) (*Runner, error) {
 	// setup a proxy writer to automatically upload results to PDCP
 	runner.output = runner.setupPDCPUpload(outputWriter)
 
+	if options.EvidenceBundle != "" {
+		evidenceOpts := &evidence.Options{File: options.EvidenceBundle}
+		if options.StoreResponse {
+			evidenceOpts.StoreResponseDir = options.StoreResponseDir
+		}
+		if options.EvidenceBundleSign {
+			// keys are not generated here as it requires user input
+			tsigner, err := evidence.NewSigner()
+			if err != nil {
+				return nil, errors.Wrap(err, "could not load nuclei user certificate and private key to sign evidence bundle (generate them using -sign)")
+			}
+			evidenceOpts.Signer = tsigner
+		}
+		bundle, err := evidence.New(evidenceOpts)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create evidence bundle")
+		}
+		runner.evidence = bundle
+		runner.output = bundle.Writer(runner.output)
+	}
+
 	if options.JSONL && options.EnableProgressBar {
 		options.StatsJSON = true
 	}
@@ -274,6 +309,7 @@ func New(options *types.Options) (*Runner, error) {
 	runner.resumeCfg = resumeCfg
 
 	opts := interactsh.DefaultOptions(runner.output, runner.issuesClient, runner.progress)
+	opts.Suppressor = runner.suppressor
 	opts.Debug = runner.options.Debug
 	opts.NoColor = runner.options.NoColor
 	if options.InteractshURL != "" {
@@ -349,6 +385,7 @@ func (r *Runner) Close() {
 	if r.browser != nil {
 		r.browser.Close()
 	}
+	r.evidence.Close()
 }
 
 // setupPDCPUpload sets up the PDCP upload writer
@@ -420,6 +457,25 @@ func (r *Runner) RunEnumeration() error {
 		ResumeCfg:       r.resumeCfg,
 		ExcludeMatchers: excludematchers.New(r.options.ExcludeMatchers),
 		InputHelper:     input.NewHelper(),
+		Suppressor:      r.suppressor,
+		Evidence:        r.evidence,
+	}
+
+	if r.options.TemplateStatsExport != "" {
+		executorOpts.ExecutionStats = executionstats.New()
+	}
+
+	if len(r.options.SecretsFile) > 0 {
+		// tokens are fetched with a tls verifying client honouring proxy and dialer settings
+		tokenClient, err := httpclientpool.GetTokenClient(r.options)
+		if err != nil {
+			return errors.Wrap(err, "could not get http client")
+		}
+		authProvider, err := authprovider.NewAuthProvider(&authprovider.AuthProviderOptions{SecretsFiles: r.options.SecretsFile, HTTPClient: tokenClient})
+		if err != nil {
+			return errors.Wrap(err, "could not create auth provider")
+		}
+		executorOpts.AuthProvider = authProvider
 	}
 
 	if r.options.ShouldUseHostError() {
@@ -455,6 +511,14 @@ func (r *Runner) RunEnumeration() error {
 		return nil // exit
 	}
 	store.Load()
+	if r.evidence != nil {
+		for _, template := range store.Templates() {
+			r.evidence.AddTemplate(template.ID, template.Path, template.Verified)
+		}
+		for _, workflow := range store.Workflows() {
+			r.evidence.AddTemplate(workflow.ID, workflow.Path, workflow.Verified)
+		}
+	}
 	// TODO: remove below functions after v3 or update warning messages
 	disk.PrintDeprecatedPathsMsgIfApplicable(r.options.Silent)
 	templates.PrintDeprecatedProtocolNameMsgIfApplicable(r.options.Silent, r.options.Verbose)
@@ -512,6 +576,22 @@ func (r *Runner) RunEnumeration() error {
 		_ = executorOpts.InputHelper.Close()
 	}
 
+	if executorOpts.ExecutionStats != nil {
+		if err := executorOpts.ExecutionStats.WriteJSON(r.options.TemplateStatsExport); err != nil {
+			gologger.Warning().Msgf("Could not export template execution stats: %s\n", err)
+		}
+	}
+	if r.evidence != nil {
+		if err := r.evidence.Write(r.options); err != nil {
+			gologger.Warning().Msgf("Could not export evidence bundle: %s\n", err)
+		} else {
+			gologger.Info().Msgf("Evidence bundle written to %s", r.options.EvidenceBundle)
+		}
+	}
+	if count := r.suppressor.Count(); count > 0 {
+		gologger.Info().Msgf("Suppressed %d results matching suppression rules", count)
+	}
+
 	// todo: error propagation without canonical straight error check is required by cloud?
 	// use safe dereferencing to avoid potential panics in case of previous unchecked errors
 	if v := ptrutil.Safe(results); !v.Load() {
@@ -12,6 +12,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/parsers"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/executionstats"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/ratelimit"
 	errorutil "github.com/projectdiscovery/utils/errors"
@@ -40,6 +41,9 @@ func createEphemeralObjects(base *NucleiEngine, opts *types.Options) (*unsafeOpt
 		HostErrorsCache: base.hostErrCache,
 		Colorizer:       aurora.NewAurora(true),
 		ResumeCfg:       types.NewResumeCfg(),
+		AuthProvider:    base.executerOpts.AuthProvider,
+		Suppressor:      base.executerOpts.Suppressor,
+		ExecutionStats:  base.executerOpts.ExecutionStats,
 	}
 	if opts.RateLimitMinute > 0 {
 		u.executerOpts.RateLimiter = ratelimit.New(context.Background(), uint(opts.RateLimitMinute), time.Minute)
@@ -146,6 +150,12 @@ func (e *ThreadSafeNucleiEngine) ExecuteNucleiWithOpts(targets []string, opts ..
 	return nil
 }
 
+// GetExecutionStats returns the per-template execution statistics
+// aggregated over all executions so far
+func (e *ThreadSafeNucleiEngine) GetExecutionStats() []*executionstats.TemplateStats {
+	return e.eng.GetExecutionStats()
+}
+
 // Close all resources used by nuclei engine
 func (e *ThreadSafeNucleiEngine) Close() {
 	e.eng.Close()
@@ -10,10 +10,12 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/loader"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core/inputs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/evidence"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/parsers"
 	"github.com/projectdiscovery/nuclei/v3/pkg/progress"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/executionstats"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/hosterrorscache"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/headless/engine"
@@ -80,6 +82,10 @@ type NucleiEngine struct {
 	customProgress progress.Progress
 	rc             reporting.Client
 	executerOpts   protocols.ExecutorOptions
+	configFiles    []string
+	evidence       *evidence.Bundle
+	// options explicitly set using sdk options (by field name)
+	explicitOpts map[string]struct{}
 }
 
 // LoadAllTemplates loads all nuclei template based on given options
@@ -134,6 +140,12 @@ func (e *NucleiEngine) GetExecuterOptions() *protocols.ExecutorOptions {
 	return &e.executerOpts
 }
 
+// GetExecutionStats returns the per-template execution statistics
+// (requests, errors by class, matched hosts and durations) of executions so far
+func (e *NucleiEngine) GetExecutionStats() []*executionstats.TemplateStats {
+	return e.executerOpts.ExecutionStats.Stats()
+}
+
 // ParseTemplate parses a template from given data
 // template verification status can be accessed from template.Verified
 func (e *NucleiEngine) ParseTemplate(data []byte) (*templates.Template, error) {
@@ -169,6 +181,7 @@ func (e *NucleiEngine) Close() {
 	e.customWriter.Close()
 	e.hostErrCache.Close()
 	e.executerOpts.RateLimiter.Stop()
+	e.evidence.Close()
 }
 
 // ExecuteWithCallback executes templates on targets and calls callback on each result(only if results are found)
@@ -192,7 +205,22 @@ func (e *NucleiEngine) ExecuteWithCallback(callback ...func(event *output.Result
 	e.resultCallbacks = append(e.resultCallbacks, filtered...)
 
 	_ = e.engine.ExecuteScanWithOpts(e.store.Templates(), e.inputProvider, false)
-	defer e.engine.WorkPool().Wait()
+	e.engine.WorkPool().Wait()
+
+	if e.evidence != nil {
+		for _, template := range e.store.Templates() {
+			e.evidence.AddTemplate(template.ID, template.Path, template.Verified)
+		}
+		for _, workflow := range e.store.Workflows() {
+			e.evidence.AddTemplate(workflow.ID, workflow.Path, workflow.Verified)
+		}
+		if err := e.evidence.Write(e.opts); err != nil {
+			return err
+		}
+	}
+	if e.opts.TemplateStatsExport != "" {
+		return e.executerOpts.ExecutionStats.WriteJSON(e.opts.TemplateStatsExport)
+	}
 	return nil
 }
 
@@ -3,33 +3,42 @@ package nuclei
 import (
 	"context"
 	"fmt"
+	"os"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/logrusorgru/aurora"
+	"github.com/projectdiscovery/goflags"
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/gologger/levels"
 	"github.com/projectdiscovery/httpx/common/httpx"
 	"github.com/projectdiscovery/nuclei/v3/internal/runner"
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/disk"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core"
 	"github.com/projectdiscovery/nuclei/v3/pkg/core/inputs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/evidence"
 	"github.com/projectdiscovery/nuclei/v3/pkg/installer"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/progress"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/executionstats"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/hosterrorscache"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolinit"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
+	"github.com/projectdiscovery/nuclei/v3/pkg/suppression"
 	"github.com/projectdiscovery/nuclei/v3/pkg/testutils"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/ratelimit"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	"gopkg.in/yaml.v2"
 )
 
 // applyRequiredDefaults to options
@@ -87,8 +96,149 @@ func (e *NucleiEngine) applyRequiredDefaults() {
 	}
 }
 
+// ignoredConfigOptions contains options (by field name) which are not supported
+// by sdk and are ignored when present in config files
+var ignoredConfigOptions = map[string]struct{}{
+	"Targets":                 {},
+	"TargetsFilePath":         {},
+	"Resume":                  {},
+	"Output":                  {},
+	"JSONL":                   {},
+	"JSONExport":              {},
+	"JSONLExport":             {},
+	"MarkdownExportDirectory": {},
+	"SarifExport":             {},
+	"ReportingConfig":         {},
+	"StoreResponse":           {},
+	"UpdateTemplates":         {},
+	"EnableProgressBar":       {},
+}
+
+// markOptionsSet records given options (pointers to fields of engine options)
+// as explicitly set so that they are not overwritten by config files
+func (e *NucleiEngine) markOptionsSet(fields ...interface{}) {
+	e.markOptions(false, fields...)
+}
+
+// markNonZeroOptionsSet is same as markOptionsSet but only records options
+// with non-zero values. It is used for options passed as (optional) struct fields
+func (e *NucleiEngine) markNonZeroOptionsSet(fields ...interface{}) {
+	e.markOptions(true, fields...)
+}
+
+func (e *NucleiEngine) markOptions(skipZero bool, fields ...interface{}) {
+	if e.explicitOpts == nil {
+		e.explicitOpts = make(map[string]struct{})
+	}
+	opts := reflect.ValueOf(e.opts).Elem()
+	for _, field := range fields {
+		ptr := reflect.ValueOf(field)
+		if skipZero && isZeroOption(ptr.Elem()) {
+			continue
+		}
+		for i := 0; i < opts.NumField(); i++ {
+			if addr := opts.Field(i).Addr(); addr.Pointer() == ptr.Pointer() && addr.Type() == ptr.Type() {
+				e.explicitOpts[opts.Type().Field(i).Name] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// isZeroOption checks if option has zero value
+// considering empty slices/maps as zero
+func isZeroOption(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// configFileKeys returns options (by field name) bound to keys present in config files
+// along with keys which do not belong to any option
+func configFileKeys(flagSet *goflags.FlagSet, options *types.Options, files []string) (map[string]string, []string, error) {
+	fields := make(map[string]string)
+	var unknown []string
+	opts := reflect.ValueOf(options).Elem()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		keys := make(map[string]interface{})
+		if err := yaml.Unmarshal(data, &keys); err != nil {
+			return nil, nil, err
+		}
+	keysLoop:
+		for key := range keys {
+			if fl := flagSet.CommandLine.Lookup(key); fl != nil {
+				if value := reflect.ValueOf(fl.Value); value.Kind() == reflect.Ptr {
+					for i := 0; i < opts.NumField(); i++ {
+						if opts.Field(i).Addr().Pointer() == value.Pointer() {
+							fields[opts.Type().Field(i).Name] = key
+							continue keysLoop
+						}
+					}
+				}
+			}
+			unknown = append(unknown, key)
+		}
+	}
+	return fields, unknown, nil
+}
+
+// mergeConfigFiles merges options from config files into engine options.
+// Only options which are present in config files and not explicitly set
+// using sdk options are overwritten
+func (e *NucleiEngine) mergeConfigFiles() error {
+	if len(e.configFiles) == 0 {
+		return nil
+	}
+	fromFiles := &types.Options{}
+	flagSet := runner.NewFlagSet(fromFiles, nil)
+	for _, file := range e.configFiles {
+		if err := flagSet.MergeConfigFile(file); err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not read config file %v", file)
+		}
+	}
+	fields, unknown, err := configFileKeys(flagSet, fromFiles, e.configFiles)
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not read config file")
+	}
+	for _, key := range unknown {
+		gologger.Warning().Msgf("Ignoring config option %v which is not supported by sdk\n", key)
+	}
+
+	merged := reflect.ValueOf(fromFiles).Elem()
+	current := reflect.ValueOf(e.opts).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Field(i)
+		name := current.Type().Field(i).Name
+		key, ok := fields[name]
+		if !ok || !field.CanSet() {
+			// not set in config files
+			continue
+		}
+		if _, ok := ignoredConfigOptions[name]; ok {
+			gologger.Warning().Msgf("Ignoring config option %v which is not supported by sdk\n", key)
+			continue
+		}
+		if _, ok := e.explicitOpts[name]; ok {
+			// set using sdk options
+			continue
+		}
+		field.Set(merged.Field(i))
+	}
+	return nil
+}
+
 // init
 func (e *NucleiEngine) init() error {
+	if err := e.mergeConfigFiles(); err != nil {
+		return err
+	}
+
 	if e.opts.Verbose {
 		gologger.DefaultLogger.SetMaxLevel(levels.LevelVerbose)
 	} else if e.opts.Debug {
@@ -114,6 +264,20 @@ func (e *NucleiEngine) init() error {
 	e.applyRequiredDefaults()
 	var err error
 
+	if e.opts.EvidenceBundle != "" {
+		evidenceOpts := &evidence.Options{File: e.opts.EvidenceBundle}
+		if e.opts.EvidenceBundleSign {
+			if evidenceOpts.Signer, err = evidence.NewSigner(); err != nil {
+				return errorutil.NewWithErr(err).Msgf("could not load nuclei user certificate and private key to sign evidence bundle")
+			}
+		}
+		if e.evidence, err = evidence.New(evidenceOpts); err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not create evidence bundle")
+		}
+		e.customWriter = e.evidence.Writer(e.customWriter)
+		e.interactshOpts.Output = e.customWriter
+	}
+
 	// setup progressbar
 	if e.enableStats {
 		progressInstance, progressErr := progress.NewStatsTicker(e.opts.StatsInterval, e.enableStats, e.opts.StatsJSON, false, e.opts.MetricsPort)
@@ -132,6 +296,11 @@ func (e *NucleiEngine) init() error {
 		return err
 	}
 	e.interactshOpts.IssuesClient = e.rc
+	if len(e.opts.SuppressionFile) > 0 {
+		if e.interactshOpts.Suppressor, err = suppression.New(e.opts.SuppressionFile...); err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not create suppressor")
+		}
+	}
 	if e.httpClient != nil {
 		e.interactshOpts.HTTPClient = e.httpClient
 	}
@@ -153,6 +322,22 @@ func (e *NucleiEngine) init() error {
 		Colorizer:       aurora.NewAurora(true),
 		ResumeCfg:       types.NewResumeCfg(),
 		Browser:         e.browserInstance,
+		Suppressor:      e.interactshOpts.Suppressor,
+		ExecutionStats:  executionstats.New(),
+		Evidence:        e.evidence,
+	}
+
+	if len(e.opts.SecretsFile) > 0 {
+		// tokens are fetched with a tls verifying client honouring proxy and dialer settings
+		tokenClient, err := httpclientpool.GetTokenClient(e.opts)
+		if err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not get http client")
+		}
+		authProvider, err := authprovider.NewAuthProvider(&authprovider.AuthProviderOptions{SecretsFiles: e.opts.SecretsFile, HTTPClient: tokenClient})
+		if err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not create auth provider")
+		}
+		e.executerOpts.AuthProvider = authProvider
 	}
 
 	if e.opts.RateLimitMinute > 0 {
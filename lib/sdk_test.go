@@ -1,6 +1,8 @@
 package nuclei_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	nuclei "github.com/projectdiscovery/nuclei/v3/lib"
@@ -58,3 +60,40 @@ func TestThreadSafeNuclei(t *testing.T) {
 	// wait for all scans to finish
 	defer ne.Close()
 }
+
+func TestNucleiConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(configFile, []byte("tags:\n  - cve\nseverity: critical\nbulk-size: 10\n"), 0644)
+	require.Nil(t, err)
+
+	ne, err := nuclei.NewNucleiEngine(
+		nuclei.WithConfigFile(configFile),
+		nuclei.WithTemplateFilters(nuclei.TemplateFilters{Tags: []string{"tech"}}),
+	)
+	require.Nil(t, err)
+	defer ne.Close()
+
+	opts := ne.GetExecuterOptions().Options
+	require.Equal(t, []string{"tech"}, []string(opts.Tags), "programmatic options should take precedence")
+	require.Equal(t, "critical", opts.Severities.String(), "could not load option from config file")
+	require.Equal(t, 10, opts.BulkSize, "could not load option from config file")
+
+	// values set using sdk options take precedence even if they equal defaults
+	// and values present in config files are applied even if they equal flag defaults
+	err = os.WriteFile(configFile, []byte("bulk-size: 10\ntimeout: 10\noutput: results.txt\n"), 0644)
+	require.Nil(t, err)
+	ne, err = nuclei.NewNucleiEngine(
+		nuclei.WithConfigFile(configFile),
+		nuclei.WithConcurrency(nuclei.Concurrency{TemplateConcurrency: 25, HostConcurrency: 25, HeadlessHostConcurrency: 10, HeadlessTemplateConcurrency: 10}),
+	)
+	require.Nil(t, err)
+	defer ne.Close()
+
+	opts = ne.GetExecuterOptions().Options
+	require.Equal(t, 25, opts.BulkSize, "sdk option should take precedence")
+	require.Equal(t, 10, opts.Timeout, "could not load option from config file")
+	require.Empty(t, opts.Output, "unsupported config option should be ignored")
+
+	_, err = nuclei.NewNucleiEngine(nuclei.WithConfigFile("non-existent-config"))
+	require.NotNil(t, err)
+}
@@ -0,0 +1,173 @@
+package authx
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// AuthType is the type of authentication strategy
+type AuthType string
+
+const (
+	BasicAuth       AuthType = "BasicAuth"
+	BearerTokenAuth AuthType = "BearerToken"
+	HeadersAuth     AuthType = "Header"
+	CookiesAuth     AuthType = "Cookie"
+	NTLMAuth        AuthType = "NTLM"
+	NegotiateAuth   AuthType = "Negotiate"
+	OAuth2Auth      AuthType = "OAuth2"
+)
+
+// SupportedAuthTypes returns the supported auth types
+func SupportedAuthTypes() []string {
+	return []string{
+		string(BasicAuth),
+		string(BearerTokenAuth),
+		string(HeadersAuth),
+		string(CookiesAuth),
+		string(NTLMAuth),
+		string(NegotiateAuth),
+		string(OAuth2Auth),
+	}
+}
+
+// Authx is structure to hold all auth secrets of a secrets file
+type Authx struct {
+	ID      string     `json:"id" yaml:"id"`
+	Info    model.Info `json:"info" yaml:"info"`
+	Secrets []Secret   `json:"static" yaml:"static"`
+}
+
+// Secret is a struct to hold the secret for a list of domains
+type Secret struct {
+	Type         string   `json:"type" yaml:"type"`
+	Domains      []string `json:"domains" yaml:"domains"`
+	DomainsRegex []string `json:"domains-regex" yaml:"domains-regex"`
+	Headers      []KV     `json:"headers" yaml:"headers"`
+	Cookies      []KV     `json:"cookies" yaml:"cookies"`
+	Username     string   `json:"username" yaml:"username"` // can be either email or username
+	Password     string   `json:"password" yaml:"password"`
+	Domain       string   `json:"domain" yaml:"domain"` // windows domain for ntlm/negotiate auth
+	Token        string   `json:"token" yaml:"token"`   // Bearer Auth token
+
+	// oauth2 token endpoint configuration
+	TokenURL     string   `json:"token-url" yaml:"token-url"`
+	GrantType    string   `json:"grant-type" yaml:"grant-type"` // client_credentials (default) or password
+	ClientID     string   `json:"client-id" yaml:"client-id"`
+	ClientSecret string   `json:"client-secret" yaml:"client-secret"`
+	Scopes       []string `json:"scopes" yaml:"scopes"`
+}
+
+// KV is a key-value pair used for headers and cookies
+type KV struct {
+	Key   string `json:"key" yaml:"key"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// Validate validates the secret
+func (s *Secret) Validate() error {
+	if len(s.Domains) == 0 && len(s.DomainsRegex) == 0 {
+		return errorutil.New("domains or domains-regex cannot be empty")
+	}
+	for _, domain := range s.DomainsRegex {
+		if _, err := regexp.Compile(domain); err != nil {
+			return errorutil.NewWithErr(err).Msgf("invalid domain regex %v", domain)
+		}
+	}
+
+	switch {
+	case strings.EqualFold(s.Type, string(BasicAuth)), strings.EqualFold(s.Type, string(NTLMAuth)), strings.EqualFold(s.Type, string(NegotiateAuth)):
+		if s.Username == "" {
+			return errorutil.New("username cannot be empty in %v auth", s.Type)
+		}
+		if s.Password == "" {
+			return errorutil.New("password cannot be empty in %v auth", s.Type)
+		}
+	case strings.EqualFold(s.Type, string(BearerTokenAuth)):
+		if s.Token == "" {
+			return errorutil.New("token cannot be empty in bearer token auth")
+		}
+	case strings.EqualFold(s.Type, string(HeadersAuth)):
+		if len(s.Headers) == 0 {
+			return errorutil.New("headers cannot be empty in headers auth")
+		}
+	case strings.EqualFold(s.Type, string(CookiesAuth)):
+		if len(s.Cookies) == 0 {
+			return errorutil.New("cookies cannot be empty in cookies auth")
+		}
+	case strings.EqualFold(s.Type, string(OAuth2Auth)):
+		if s.TokenURL == "" {
+			return errorutil.New("token-url cannot be empty in oauth2 auth")
+		}
+		if tokenURL, err := url.Parse(s.TokenURL); err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+			return errorutil.New("invalid token-url %v in oauth2 auth", s.TokenURL)
+		}
+		if s.ClientID == "" {
+			return errorutil.New("client-id cannot be empty in oauth2 auth")
+		}
+		switch {
+		case s.GrantType == "", strings.EqualFold(s.GrantType, OAuth2ClientCredentialsGrant):
+			if s.ClientSecret == "" {
+				return errorutil.New("client-secret cannot be empty in oauth2 client credentials grant")
+			}
+		case strings.EqualFold(s.GrantType, OAuth2PasswordGrant):
+			if s.Username == "" || s.Password == "" {
+				return errorutil.New("username and password cannot be empty in oauth2 password grant")
+			}
+		default:
+			return errorutil.New("invalid oauth2 grant-type %v, supported grant types are: %v,%v", s.GrantType, OAuth2ClientCredentialsGrant, OAuth2PasswordGrant)
+		}
+	default:
+		return errorutil.New("invalid type %v, supported types are: %v", s.Type, strings.Join(SupportedAuthTypes(), ","))
+	}
+	return nil
+}
+
+// GetStrategy returns the auth strategy for the secret
+func (s *Secret) GetStrategy() AuthStrategy {
+	switch {
+	case strings.EqualFold(s.Type, string(BasicAuth)):
+		return NewBasicAuthStrategy(s)
+	case strings.EqualFold(s.Type, string(BearerTokenAuth)):
+		return NewBearerTokenAuthStrategy(s)
+	case strings.EqualFold(s.Type, string(HeadersAuth)):
+		return NewHeadersAuthStrategy(s)
+	case strings.EqualFold(s.Type, string(CookiesAuth)):
+		return NewCookiesAuthStrategy(s)
+	case strings.EqualFold(s.Type, string(NTLMAuth)), strings.EqualFold(s.Type, string(NegotiateAuth)):
+		return NewNTLMAuthStrategy(s)
+	case strings.EqualFold(s.Type, string(OAuth2Auth)):
+		return NewOAuth2AuthStrategy(s)
+	}
+	return nil
+}
+
+// GetAuthDataFromFile reads the auth data from file (yaml or json)
+func GetAuthDataFromFile(file string) (*Authx, error) {
+	ext := strings.ToLower(filepath.Ext(file))
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return nil, errorutil.New("invalid file extension: supported extensions are .yaml,.yml and .json got %v", ext)
+	}
+	bin, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not read secrets file %v", file)
+	}
+	// json is a subset of yaml hence a single parser is enough
+	return GetAuthDataFromYAML(bin)
+}
+
+// GetAuthDataFromYAML reads the auth data from yaml
+func GetAuthDataFromYAML(data []byte) (*Authx, error) {
+	var auth Authx
+	if err := yaml.Unmarshal(data, &auth); err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not unmarshal secrets data")
+	}
+	return &auth, nil
+}
@@ -0,0 +1,38 @@
+package authx
+
+import (
+	"net/http"
+)
+
+var (
+	_ NegotiatingStrategy = &NTLMAuthStrategy{}
+)
+
+// NTLMAuthStrategy is a strategy for NTLM auth. It is also used for Negotiate
+// auth which is performed with NTLM tokens wrapped in it, Kerberos is not supported.
+//
+// The credentials are attached as basic auth and converted to the
+// NTLM handshake by the negotiating http client. The handshake is not
+// supported for unsafe and pipelined http requests.
+type NTLMAuthStrategy struct {
+	Data *Secret
+}
+
+// NewNTLMAuthStrategy creates a new ntlm auth strategy
+func NewNTLMAuthStrategy(data *Secret) *NTLMAuthStrategy {
+	return &NTLMAuthStrategy{Data: data}
+}
+
+// Apply applies the ntlm auth strategy to the request
+func (s *NTLMAuthStrategy) Apply(req *http.Request) {
+	username := s.Data.Username
+	if s.Data.Domain != "" {
+		username = s.Data.Domain + `\` + username
+	}
+	req.SetBasicAuth(username, s.Data.Password)
+}
+
+// RequiresNegotiation returns true as ntlm requires a handshake
+func (s *NTLMAuthStrategy) RequiresNegotiation() bool {
+	return true
+}
@@ -0,0 +1,179 @@
+package authx
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+var (
+	_ AuthStrategy = &OAuth2AuthStrategy{}
+)
+
+const (
+	// OAuth2ClientCredentialsGrant is the client credentials grant type (default)
+	OAuth2ClientCredentialsGrant = "client_credentials"
+	// OAuth2PasswordGrant is the resource owner password credentials grant type
+	OAuth2PasswordGrant = "password"
+)
+
+var (
+	// OAuth2RefreshBeforeExpiry is the duration before expiry at which
+	// tokens are considered expired and refreshed from token endpoint
+	OAuth2RefreshBeforeExpiry = time.Minute
+	// OAuth2RequestTimeout is the timeout of token endpoint requests
+	// when no http client is configured for the strategy
+	OAuth2RequestTimeout = 30 * time.Second
+	// OAuth2RetryBackoff is the initial duration for which token endpoint
+	// failures are cached before fetching a token again (doubled on each failure)
+	OAuth2RetryBackoff = 5 * time.Second
+	// OAuth2MaxRetryBackoff is the maximum duration for which token endpoint failures are cached
+	OAuth2MaxRetryBackoff = 5 * time.Minute
+)
+
+// OAuth2AuthStrategy is a strategy for oauth2 auth. It fetches access tokens
+// from token endpoint using client credentials or password grant and
+// applies them as bearer token. Tokens are cached and refreshed automatically
+// before they expire, failures are cached with an exponential backoff.
+type OAuth2AuthStrategy struct {
+	Data *Secret
+	// HTTPClient is the client used for token endpoint requests (optional)
+	HTTPClient *http.Client
+	source     oauth2.TokenSource
+
+	mu         sync.Mutex
+	failures   int
+	lastErr    error
+	retryAfter time.Time
+}
+
+// NewOAuth2AuthStrategy creates a new oauth2 auth strategy
+func NewOAuth2AuthStrategy(data *Secret) *OAuth2AuthStrategy {
+	strategy := &OAuth2AuthStrategy{Data: data}
+
+	var source oauth2.TokenSource
+	if strings.EqualFold(data.GrantType, OAuth2PasswordGrant) {
+		source = &passwordTokenSource{
+			ctx: strategy.context,
+			config: &oauth2.Config{
+				ClientID:     data.ClientID,
+				ClientSecret: data.ClientSecret,
+				Endpoint:     oauth2.Endpoint{TokenURL: data.TokenURL},
+				Scopes:       data.Scopes,
+			},
+			username: data.Username,
+			password: data.Password,
+		}
+	} else {
+		config := &clientcredentials.Config{
+			ClientID:     data.ClientID,
+			ClientSecret: data.ClientSecret,
+			TokenURL:     data.TokenURL,
+			Scopes:       data.Scopes,
+		}
+		source = tokenSourceFunc(func() (*oauth2.Token, error) {
+			return config.Token(strategy.context())
+		})
+	}
+	strategy.source = oauth2.ReuseTokenSourceWithExpiry(nil, source, OAuth2RefreshBeforeExpiry)
+	return strategy
+}
+
+// Apply applies the oauth2 auth strategy to the request
+func (s *OAuth2AuthStrategy) Apply(req *http.Request) {
+	token, err := s.token()
+	if err != nil {
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+}
+
+// token returns a valid token or the cached error of the
+// token endpoint if the backoff duration has not elapsed yet
+func (s *OAuth2AuthStrategy) token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastErr != nil && time.Now().Before(s.retryAfter) {
+		return nil, s.lastErr
+	}
+	token, err := s.source.Token()
+	if err != nil {
+		// failures are only logged once until a token is fetched again
+		if s.lastErr == nil {
+			gologger.Warning().Msgf("Could not fetch oauth2 token from %v, sending requests without auth: %s\n", s.Data.TokenURL, err)
+		}
+		s.failures++
+		s.lastErr = err
+		s.retryAfter = time.Now().Add(retryBackoff(s.failures))
+		return nil, err
+	}
+	s.failures, s.lastErr = 0, nil
+	return token, nil
+}
+
+// context returns the context used for token endpoint requests
+func (s *OAuth2AuthStrategy) context() context.Context {
+	client := s.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: OAuth2RequestTimeout}
+	}
+	return context.WithValue(context.Background(), oauth2.HTTPClient, client)
+}
+
+// retryBackoff returns the backoff duration after given number of failures
+func retryBackoff(failures int) time.Duration {
+	backoff := OAuth2RetryBackoff
+	for i := 1; i < failures && backoff < OAuth2MaxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > OAuth2MaxRetryBackoff {
+		backoff = OAuth2MaxRetryBackoff
+	}
+	return backoff
+}
+
+// tokenSourceFunc is an adapter to use a function as oauth2.TokenSource
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+// Token returns a new token
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// passwordTokenSource is a token source for password grant. It uses the
+// refresh token of last token (if any) and falls back to credentials
+type passwordTokenSource struct {
+	ctx      func() context.Context
+	config   *oauth2.Config
+	username string
+	password string
+
+	mu   sync.Mutex
+	last *oauth2.Token
+}
+
+// Token returns a new token
+func (p *passwordTokenSource) Token() (*oauth2.Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.last != nil && p.last.RefreshToken != "" {
+		if token, err := p.config.TokenSource(p.ctx(), &oauth2.Token{RefreshToken: p.last.RefreshToken}).Token(); err == nil {
+			p.last = token
+			return token, nil
+		}
+	}
+	token, err := p.config.PasswordCredentialsToken(p.ctx(), p.username, p.password)
+	if err != nil {
+		return nil, err
+	}
+	p.last = token
+	return token, nil
+}
@@ -0,0 +1,30 @@
+package authx
+
+import (
+	"net/http"
+)
+
+// AuthStrategy is an interface for auth strategies
+// basic auth , bearer token, headers, cookies etc
+type AuthStrategy interface {
+	// Apply applies the strategy to the request
+	Apply(*http.Request)
+}
+
+// NegotiatingStrategy is implemented by auth strategies which require a
+// challenge-response handshake with the server (ex: ntlm, negotiate)
+// and hence a http client capable of performing it
+type NegotiatingStrategy interface {
+	AuthStrategy
+	// RequiresNegotiation returns true if the strategy requires a handshake
+	RequiresNegotiation() bool
+}
+
+// RequiresNegotiation returns true if given strategy requires
+// a challenge-response handshake with the server
+func RequiresNegotiation(strategy AuthStrategy) bool {
+	if negotiating, ok := strategy.(NegotiatingStrategy); ok {
+		return negotiating.RequiresNegotiation()
+	}
+	return false
+}
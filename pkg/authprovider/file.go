@@ -0,0 +1,109 @@
+package authprovider
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider/authx"
+	errorutil "github.com/projectdiscovery/utils/errors"
+)
+
+// FileAuthProvider is an auth provider for file based auth
+// it accepts a list of secrets files and returns its provider
+type FileAuthProvider struct {
+	store      []*authx.Secret
+	compiled   []compiledSecret
+	domains    map[string]authx.AuthStrategy
+	strategies []authx.AuthStrategy
+}
+
+// compiledSecret is a secret strategy with its compiled domain regex
+type compiledSecret struct {
+	regex    *regexp.Regexp
+	strategy authx.AuthStrategy
+}
+
+// NewFileAuthProvider creates a new file based auth provider
+func NewFileAuthProvider(paths ...string) (AuthProvider, error) {
+	f := &FileAuthProvider{
+		domains: make(map[string]authx.AuthStrategy),
+	}
+	for _, path := range paths {
+		store, err := authx.GetAuthDataFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for i := range store.Secrets {
+			secret := &store.Secrets[i]
+			if err := secret.Validate(); err != nil {
+				return nil, errorutil.NewWithErr(err).Msgf("invalid secret in file %v", path)
+			}
+			if strings.EqualFold(secret.Type, string(authx.NegotiateAuth)) {
+				gologger.Warning().Msgf("Negotiate auth in %v is performed with ntlm tokens, kerberos is not supported", path)
+			}
+			f.store = append(f.store, secret)
+		}
+	}
+	if len(f.store) == 0 {
+		return nil, ErrNoSecrets
+	}
+	f.init()
+	return f, nil
+}
+
+// init initializes the file auth provider lookup maps
+func (f *FileAuthProvider) init() {
+	for _, secret := range f.store {
+		// strategies are created once and shared since some
+		// of them are stateful (ex: oauth2 token cache)
+		strategy := secret.GetStrategy()
+		f.strategies = append(f.strategies, strategy)
+		for _, domain := range secret.DomainsRegex {
+			// already validated while loading secrets
+			f.compiled = append(f.compiled, compiledSecret{regex: regexp.MustCompile(domain), strategy: strategy})
+		}
+		for _, domain := range secret.Domains {
+			f.domains[strings.ToLower(strings.TrimSpace(domain))] = strategy
+		}
+	}
+}
+
+// setHTTPClient sets the http client of strategies fetching tokens
+func (f *FileAuthProvider) setHTTPClient(client *http.Client) {
+	for _, strategy := range f.strategies {
+		if oauth2, ok := strategy.(*authx.OAuth2AuthStrategy); ok {
+			oauth2.HTTPClient = client
+		}
+	}
+}
+
+// LookupAddr looks up a given domain/address and returns appropriate auth strategy
+func (f *FileAuthProvider) LookupAddr(addr string) authx.AuthStrategy {
+	// secrets may be given for either host:port or host
+	candidates := []string{strings.ToLower(addr)}
+	if host, _, err := net.SplitHostPort(candidates[0]); err == nil {
+		candidates = append(candidates, host)
+	}
+	for _, candidate := range candidates {
+		if strategy, ok := f.domains[candidate]; ok {
+			return strategy
+		}
+	}
+	for _, compiled := range f.compiled {
+		for _, candidate := range candidates {
+			if compiled.regex.MatchString(candidate) {
+				return compiled.strategy
+			}
+		}
+	}
+	return nil
+}
+
+// LookupURL looks up a given URL and returns appropriate auth strategy
+func (f *FileAuthProvider) LookupURL(u *url.URL) authx.AuthStrategy {
+	return f.LookupAddr(u.Host)
+}
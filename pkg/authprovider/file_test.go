@@ -0,0 +1,216 @@
+package authprovider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider/authx"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileAuthProvider(t *testing.T) {
+	secrets := `id: test-secrets
+info:
+  name: test secrets
+  author: pdteam
+static:
+  - type: ntlm
+    domains:
+      - exchange.corp.local
+    username: scanner
+    password: password
+    domain: CORP
+  - type: BearerToken
+    domains-regex:
+      - .*\.api\.corp\.local
+    token: test-token
+`
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+
+	provider, err := NewAuthProvider(&AuthProviderOptions{SecretsFiles: []string{file}})
+	require.Nil(t, err, "could not create auth provider")
+
+	t.Run("ntlm", func(t *testing.T) {
+		strategy := provider.LookupAddr("exchange.corp.local:443")
+		require.NotNil(t, strategy, "could not lookup ntlm strategy")
+		require.True(t, authx.RequiresNegotiation(strategy), "ntlm strategy should require negotiation")
+
+		req, _ := http.NewRequest(http.MethodGet, "https://exchange.corp.local/owa", nil)
+		strategy.Apply(req)
+		username, password, ok := req.BasicAuth()
+		require.True(t, ok, "credentials were not applied")
+		require.Equal(t, `CORP\scanner`, username, "could not get correct username")
+		require.Equal(t, "password", password, "could not get correct password")
+	})
+
+	t.Run("regex", func(t *testing.T) {
+		strategy := provider.LookupAddr("v1.api.corp.local")
+		require.NotNil(t, strategy, "could not lookup bearer strategy")
+		require.False(t, authx.RequiresNegotiation(strategy), "bearer strategy should not require negotiation")
+
+		req, _ := http.NewRequest(http.MethodGet, "https://v1.api.corp.local", nil)
+		strategy.Apply(req)
+		require.Equal(t, "Bearer test-token", req.Header.Get("Authorization"), "could not get correct header")
+	})
+
+	t.Run("no-match", func(t *testing.T) {
+		require.Nil(t, provider.LookupAddr("scanme.sh"), "got strategy for unknown host")
+	})
+}
+
+func TestFileAuthProviderOAuth2(t *testing.T) {
+	var issued atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		// token expires before the refresh window hence is refreshed on every use
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":30}`, issued.Add(1))
+	}))
+	defer ts.Close()
+
+	secrets := fmt.Sprintf(`id: test-secrets
+info:
+  name: test secrets
+  author: pdteam
+static:
+  - type: oauth2
+    domains:
+      - api.corp.local
+    token-url: %s/token
+    client-id: scanner
+    client-secret: secret
+`, ts.URL)
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+
+	provider, err := NewAuthProvider(&AuthProviderOptions{SecretsFiles: []string{file}})
+	require.Nil(t, err, "could not create auth provider")
+
+	for i := 1; i <= 2; i++ {
+		strategy := provider.LookupAddr("api.corp.local")
+		require.NotNil(t, strategy, "could not lookup oauth2 strategy")
+
+		req, _ := http.NewRequest(http.MethodGet, "https://api.corp.local", nil)
+		strategy.Apply(req)
+		require.Equal(t, fmt.Sprintf("Bearer token-%d", i), req.Header.Get("Authorization"), "could not get refreshed token")
+	}
+}
+
+func TestFileAuthProviderOAuth2TokenReuse(t *testing.T) {
+	var issued atomic.Int32
+	var failing atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer ts.Close()
+
+	newProvider := func(t *testing.T) AuthProvider {
+		secrets := fmt.Sprintf(`static:
+  - type: oauth2
+    domains:
+      - api.corp.local
+    token-url: %s/token
+    client-id: scanner
+    client-secret: secret
+`, ts.URL)
+		file := filepath.Join(t.TempDir(), "secrets.yaml")
+		require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+		provider, err := NewAuthProvider(&AuthProviderOptions{SecretsFiles: []string{file}, HTTPClient: ts.Client()})
+		require.Nil(t, err, "could not create auth provider")
+		return provider
+	}
+
+	t.Run("valid-token", func(t *testing.T) {
+		issued.Store(0)
+		provider := newProvider(t)
+		for i := 0; i < 3; i++ {
+			req, _ := http.NewRequest(http.MethodGet, "https://api.corp.local", nil)
+			provider.LookupAddr("api.corp.local").Apply(req)
+			require.Equal(t, "Bearer token", req.Header.Get("Authorization"), "could not get token")
+		}
+		require.Equal(t, int32(1), issued.Load(), "valid token was not reused")
+	})
+
+	t.Run("failure-backoff", func(t *testing.T) {
+		issued.Store(0)
+		failing.Store(true)
+		defer failing.Store(false)
+
+		provider := newProvider(t)
+		for i := 0; i < 3; i++ {
+			req, _ := http.NewRequest(http.MethodGet, "https://api.corp.local", nil)
+			provider.LookupAddr("api.corp.local").Apply(req)
+			require.Empty(t, req.Header.Get("Authorization"), "got token from failing endpoint")
+		}
+		require.Equal(t, int32(1), issued.Load(), "token endpoint failure was not cached")
+	})
+}
+
+func TestFileAuthProviderInvalidOAuth2(t *testing.T) {
+	secrets := `static:
+  - type: oauth2
+    domains:
+      - api.corp.local
+    token-url: not-a-url
+    client-id: scanner
+    client-secret: secret
+`
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+
+	_, err := NewAuthProvider(&AuthProviderOptions{SecretsFiles: []string{file}})
+	require.NotNil(t, err, "invalid oauth2 token-url was accepted")
+}
+
+func TestFileAuthProviderOAuth2SelfSigned(t *testing.T) {
+	options := types.DefaultOptions()
+	require.Nil(t, protocolstate.Init(options), "could not initialize protocolstate")
+
+	var issued atomic.Int32
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer ts.Close()
+
+	secrets := fmt.Sprintf(`static:
+  - type: oauth2
+    domains:
+      - api.corp.local
+    token-url: %s/token
+    client-id: scanner
+    client-secret: secret
+`, ts.URL)
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+
+	tokenClient, err := httpclientpool.GetTokenClient(options)
+	require.Nil(t, err, "could not get token client")
+	provider, err := NewAuthProvider(&AuthProviderOptions{SecretsFiles: []string{file}, HTTPClient: tokenClient})
+	require.Nil(t, err, "could not create auth provider")
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.corp.local", nil)
+	provider.LookupAddr("api.corp.local").Apply(req)
+	require.Empty(t, req.Header.Get("Authorization"), "got token from endpoint with self-signed certificate")
+	require.Equal(t, int32(0), issued.Load(), "credentials were sent to endpoint with self-signed certificate")
+}
@@ -0,0 +1,51 @@
+// Package authprovider implements a lookup of authentication strategies
+// (secrets) for scanned targets based on their domain / address.
+package authprovider
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider/authx"
+	errorutil "github.com/projectdiscovery/utils/errors"
+)
+
+var (
+	// ErrNoSecrets is returned when no secrets are provided
+	ErrNoSecrets = errorutil.New("no secrets in given provider")
+)
+
+// AuthProvider is an interface for auth providers
+// It implements a data structure suitable for quick o(1) lookups
+// and also supports domain regex matching
+type AuthProvider interface {
+	// LookupAddr looks up a given domain/address and returns appropriate auth strategy
+	// for it (accepted inputs are scanme.sh or scanme.sh:443)
+	LookupAddr(string) authx.AuthStrategy
+	// LookupURL looks up a given URL and returns appropriate auth strategy
+	LookupURL(*url.URL) authx.AuthStrategy
+}
+
+// AuthProviderOptions contains options for the auth provider
+type AuthProviderOptions struct {
+	// File based auth provider options
+	SecretsFiles []string
+	// HTTPClient is the client used by strategies fetching tokens (ex: oauth2)
+	// so that they honour proxy and dialer settings (optional)
+	HTTPClient *http.Client
+}
+
+// NewAuthProvider creates a new auth provider from the given options
+func NewAuthProvider(options *AuthProviderOptions) (AuthProvider, error) {
+	if len(options.SecretsFiles) == 0 {
+		return nil, ErrNoSecrets
+	}
+	provider, err := NewFileAuthProvider(options.SecretsFiles...)
+	if err != nil {
+		return nil, err
+	}
+	if options.HTTPClient != nil {
+		provider.(*FileAuthProvider).setHTTPClient(options.HTTPClient)
+	}
+	return provider, nil
+}
@@ -1 +1,86 @@
 package core
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/javascript"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
+	"github.com/projectdiscovery/nuclei/v3/pkg/testutils"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types/scanstrategy"
+	"github.com/stretchr/testify/require"
+)
+
+// mockProgress counts total and completed requests
+type mockProgress struct {
+	testutils.MockProgressClient
+	total    atomic.Int64
+	requests atomic.Int64
+}
+
+func (m *mockProgress) Init(hostCount int64, rulesCount int, requestCount int64) {
+	m.total.Store(requestCount)
+}
+
+func (m *mockProgress) IncrementRequests() {
+	m.requests.Add(1)
+}
+
+// mockInputProvider is an input provider for static inputs
+type mockInputProvider struct {
+	inputs []*contextargs.MetaInput
+}
+
+func (m *mockInputProvider) Count() int64 {
+	return int64(len(m.inputs))
+}
+
+func (m *mockInputProvider) Scan(callback func(value *contextargs.MetaInput) bool) {
+	for _, input := range m.inputs {
+		if !callback(input) {
+			return
+		}
+	}
+}
+
+func (m *mockInputProvider) Set(value string) {
+	m.inputs = append(m.inputs, &contextargs.MetaInput{Input: value})
+}
+
+func TestExecuteServiceHintsProgress(t *testing.T) {
+	for _, strategy := range []string{scanstrategy.TemplateSpray.String(), scanstrategy.HostSpray.String()} {
+		t.Run(strategy, func(t *testing.T) {
+			progress := &mockProgress{}
+			template := &templates.Template{
+				ID:                 "ssh-detect",
+				RequestsJavascript: []*javascript.Request{{Args: map[string]interface{}{"Port": "22"}}},
+				TotalRequests:      1,
+				Executer: &mockExecuter{executeHook: func(input *contextargs.MetaInput) {
+					progress.IncrementRequests()
+				}},
+			}
+			target := &mockInputProvider{inputs: []*contextargs.MetaInput{
+				{Input: "192.168.1.1", Services: []string{"ssh"}},
+				{Input: "192.168.1.2", Services: []string{"mysql"}},
+			}}
+
+			options := types.DefaultOptions()
+			options.ScanStrategy = strategy
+			engine := New(options)
+			engine.SetExecuterOptions(protocols.ExecutorOptions{
+				Options:   options,
+				Progress:  progress,
+				ResumeCfg: types.NewResumeCfg(),
+				Colorizer: aurora.NewAurora(false),
+			})
+			engine.ExecuteScanWithOpts([]*templates.Template{template}, target, true)
+
+			require.Equal(t, int64(2), progress.total.Load(), "could not get correct total requests")
+			require.Equal(t, progress.total.Load(), progress.requests.Load(), "progress did not reach total with skipped target")
+		})
+	}
+}
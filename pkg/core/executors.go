@@ -76,6 +76,12 @@ func (e *Engine) executeTemplateWithTargets(template *templates.Template, target
 	}
 
 	target.Scan(func(scannedValue *contextargs.MetaInput) bool {
+		// Skip if the target service hints do not match the template
+		if !template.MatchesServiceHints(scannedValue) {
+			e.skipTemplateRequests(template)
+			return true
+		}
+
 		// Best effort to track the host progression
 		// skips indexes lower than the minimum in-flight at interruption time
 		var skip bool
@@ -157,6 +163,12 @@ func (e *Engine) executeTemplatesOnTarget(alltemplates []*templates.Template, ta
 	wp := e.GetWorkPool()
 
 	for _, tpl := range alltemplates {
+		// Skip if the target service hints do not match the template
+		if !tpl.MatchesServiceHints(target) {
+			e.skipTemplateRequests(tpl)
+			continue
+		}
+
 		var sg *sizedwaitgroup.SizedWaitGroup
 		if tpl.Type() == types.HeadlessProtocol {
 			sg = wp.Headless
@@ -241,3 +253,15 @@ func (e *Engine) ChildExecuter() *ChildExecuter {
 		results: &atomic.Bool{},
 	}
 }
+
+// skipTemplateRequests marks requests of template skipped for a target as completed
+// since they are counted in the progress total
+func (e *Engine) skipTemplateRequests(template *templates.Template) {
+	// requests in workflows are not counted in total
+	if e.executerOpts.Progress == nil || len(template.Workflows) > 0 {
+		return
+	}
+	for i := 0; i < template.TotalRequests; i++ {
+		e.executerOpts.Progress.IncrementRequests()
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/projectdiscovery/hmap/filekv"
 	"github.com/projectdiscovery/hmap/store/hybrid"
 	"github.com/projectdiscovery/mapcidr/asn"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/uncover"
@@ -208,6 +209,15 @@ func (i *Input) scanInputFromReader(reader io.Reader) {
 
 // Set normalizes and stores passed input values
 func (i *Input) Set(value string) {
+	// targets may carry a port list and service hints (ex: host:22,2222,ssh)
+	targets, services := input.ParseServiceHints(value)
+	for _, target := range targets {
+		i.set(target, services)
+	}
+}
+
+// set normalizes and stores a single input value with optional service hints
+func (i *Input) set(value string, services []string) {
 	URL := strings.TrimSpace(value)
 	if URL == "" {
 		return
@@ -221,14 +231,14 @@ func (i *Input) Set(value string) {
 			}
 			return fmt.Sprintf("got empty hostname for %v skipping ip selection", URL)
 		})
-		metaInput := &contextargs.MetaInput{Input: URL}
+		metaInput := &contextargs.MetaInput{Input: URL, Services: services}
 		i.setItem(metaInput)
 		return
 	}
 
 	// Check if input is ip or hostname
 	if iputil.IsIP(urlx.Hostname()) {
-		metaInput := &contextargs.MetaInput{Input: URL}
+		metaInput := &contextargs.MetaInput{Input: URL, Services: services}
 		i.setItem(metaInput)
 		return
 	}
@@ -249,7 +259,7 @@ func (i *Input) Set(value string) {
 					if ip == "" {
 						continue
 					}
-					metaInput := &contextargs.MetaInput{Input: value, CustomIP: ip}
+					metaInput := &contextargs.MetaInput{Input: value, CustomIP: ip, Services: services}
 					i.setItem(metaInput)
 				}
 				return
@@ -280,10 +290,10 @@ func (i *Input) Set(value string) {
 
 	for _, ip := range ips {
 		if ip != "" {
-			metaInput := &contextargs.MetaInput{Input: URL, CustomIP: ip}
+			metaInput := &contextargs.MetaInput{Input: URL, CustomIP: ip, Services: services}
 			i.setItem(metaInput)
 		} else {
-			metaInput := &contextargs.MetaInput{Input: URL}
+			metaInput := &contextargs.MetaInput{Input: URL, Services: services}
 			i.setItem(metaInput)
 		}
 	}
@@ -2,6 +2,7 @@ package inputs
 
 import (
 	"github.com/projectdiscovery/httpx/common/httpx"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils"
 )
@@ -26,7 +27,10 @@ func (s *SimpleInputProvider) Scan(callback func(value *contextargs.MetaInput) b
 
 // Set adds item to input provider
 func (s *SimpleInputProvider) Set(value string) {
-	s.Inputs = append(s.Inputs, &contextargs.MetaInput{Input: value})
+	targets, services := input.ParseServiceHints(value)
+	for _, target := range targets {
+		s.Inputs = append(s.Inputs, &contextargs.MetaInput{Input: target, Services: services})
+	}
 }
 
 // SetWithProbe adds item to input provider with http probing
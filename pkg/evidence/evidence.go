@@ -0,0 +1,429 @@
+// Package evidence packages the results of a scan along with request/response
+// evidence, screenshots, templates and scan configuration into a single
+// tar.gz bundle with a manifest and optional signature for audits.
+package evidence
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates/signer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	fileutil "github.com/projectdiscovery/utils/file"
+)
+
+const (
+	// ManifestFile is the name of manifest file in the bundle
+	ManifestFile = "manifest.json"
+	// SignatureFile is the name of manifest signature file in the bundle
+	SignatureFile = "manifest.json.sig"
+	// CertificateFile is the name of signer certificate file in the bundle
+	CertificateFile = "signer.crt"
+)
+
+// Options contains the configuration options for evidence bundle
+type Options struct {
+	// File is the tar.gz file to write the bundle to
+	File string
+	// Signer signs the manifest of the bundle (optional)
+	Signer *signer.TemplateSigner
+	// StoreResponseDir is the directory of stored responses to include (optional)
+	StoreResponseDir string
+}
+
+// Template contains the information of a template used in the scan
+type Template struct {
+	// ID is the id of the template
+	ID string `json:"id"`
+	// Path is the path of the template
+	Path string `json:"path"`
+	// SHA256 is the sha256 hash of the template contents
+	SHA256 string `json:"sha256,omitempty"`
+	// Verified defines if the template signature is verified
+	Verified bool `json:"verified"`
+}
+
+// Bundle collects the evidence of a scan and writes it as a tar.gz bundle.
+// Results and their request/response are spooled to a temporary directory
+// as they arrive so that memory usage does not grow with scan size.
+// It is safe for concurrent use
+type Bundle struct {
+	options     *Options
+	mutex       sync.Mutex
+	startedAt   time.Time
+	spoolDir    string
+	resultsFile *os.File
+	results     int
+	err         error
+	screenshots map[string]struct{}
+	templates   map[string]Template
+}
+
+// NewSigner creates a signer for the bundle manifest using nuclei user certificate
+// and private key from environment or config directory. Unlike signer.NewTemplateSigner
+// it returns an error instead of generating new keys if they are missing
+func NewSigner() (*signer.TemplateSigner, error) {
+	handler := &signer.KeyHandler{}
+	if err := handler.ReadCert(signer.CertEnvVarName, config.DefaultConfig.GetKeysDir()); err != nil {
+		return nil, err
+	}
+	if err := handler.ReadPrivateKey(signer.PrivateKeyEnvName, config.DefaultConfig.GetKeysDir()); err != nil {
+		return nil, err
+	}
+	return signer.NewTemplateSigner(handler.UserCert, handler.PrivateKey)
+}
+
+// New creates a new evidence bundle
+func New(options *Options) (*Bundle, error) {
+	if options.File == "" {
+		return nil, errorutil.New("evidence bundle file cannot be empty")
+	}
+	spoolDir, err := os.MkdirTemp("", "nuclei-evidence-*")
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not create evidence spool directory")
+	}
+	if err := os.Mkdir(filepath.Join(spoolDir, "evidence"), 0700); err != nil {
+		_ = os.RemoveAll(spoolDir)
+		return nil, errorutil.NewWithErr(err).Msgf("could not create evidence spool directory")
+	}
+	resultsFile, err := os.Create(filepath.Join(spoolDir, "results.jsonl"))
+	if err != nil {
+		_ = os.RemoveAll(spoolDir)
+		return nil, errorutil.NewWithErr(err).Msgf("could not create evidence spool file")
+	}
+	return &Bundle{
+		options:     options,
+		startedAt:   time.Now(),
+		spoolDir:    spoolDir,
+		resultsFile: resultsFile,
+		screenshots: make(map[string]struct{}),
+		templates:   make(map[string]Template),
+	}, nil
+}
+
+// AddResult adds a result event to the bundle. The result and its request/response
+// are written to the spool directory, errors are returned when the bundle is written
+func (b *Bundle) AddResult(event *output.ResultEvent) {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.err != nil || b.resultsFile == nil {
+		return
+	}
+	result := *event
+	if result.Timestamp.IsZero() {
+		result.Timestamp = time.Now()
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		b.err = errorutil.NewWithErr(err).Msgf("could not marshal result")
+		return
+	}
+	if _, err := b.resultsFile.Write(append(data, '\n')); err != nil {
+		b.err = errorutil.NewWithErr(err).Msgf("could not write result to evidence spool file")
+		return
+	}
+	b.results++
+
+	prefix := filepath.Join(b.spoolDir, "evidence", fmt.Sprintf("%05d-%s", b.results, sanitizeName(result.TemplateID)))
+	if result.Request != "" {
+		if err := os.WriteFile(prefix+"-request.txt", []byte(result.Request), 0600); err != nil {
+			b.err = errorutil.NewWithErr(err).Msgf("could not write request to evidence spool directory")
+			return
+		}
+	}
+	if result.Response != "" {
+		if err := os.WriteFile(prefix+"-response.txt", []byte(result.Response), 0600); err != nil {
+			b.err = errorutil.NewWithErr(err).Msgf("could not write response to evidence spool directory")
+			return
+		}
+	}
+}
+
+// AddScreenshot adds a screenshot file to the bundle
+func (b *Bundle) AddScreenshot(file string) {
+	if b == nil || file == "" {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.screenshots[file] = struct{}{}
+}
+
+// AddTemplate adds a template used in the scan to the bundle
+func (b *Bundle) AddTemplate(id, templatePath string, verified bool) {
+	if b == nil {
+		return
+	}
+	template := Template{ID: id, Path: templatePath, Verified: verified}
+	if data, err := os.ReadFile(templatePath); err == nil {
+		hash := sha256.Sum256(data)
+		template.SHA256 = hex.EncodeToString(hash[:])
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.templates[templatePath] = template
+}
+
+// Writer returns an output writer which adds results to the bundle
+// before forwarding them to the given writer
+func (b *Bundle) Writer(writer output.Writer) output.Writer {
+	return &bundleWriter{Writer: writer, bundle: b}
+}
+
+// bundleWriter is an output writer adding results to the bundle
+type bundleWriter struct {
+	output.Writer
+	bundle *Bundle
+}
+
+// Write adds the result to the bundle and writes it to the underlying writer
+func (w *bundleWriter) Write(event *output.ResultEvent) error {
+	// events are added before writing as raw request/response
+	// can be omitted by the writer
+	w.bundle.AddResult(event)
+	return w.Writer.Write(event)
+}
+
+// Close removes the spool directory of the bundle. Results
+// added after the bundle is closed are ignored
+func (b *Bundle) Close() {
+	if b == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.resultsFile != nil {
+		_ = b.resultsFile.Close()
+		b.resultsFile = nil
+	}
+	_ = os.RemoveAll(b.spoolDir)
+}
+
+// Write writes the bundle with given scan configuration to the bundle file.
+// It can be called multiple times in which case the bundle file contains
+// all results added so far
+func (b *Bundle) Write(options *types.Options) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.err != nil {
+		return errorutil.NewWithErr(b.err).Msgf("could not add results to evidence bundle")
+	}
+	if b.resultsFile == nil {
+		return errorutil.New("evidence bundle is closed")
+	}
+
+	file, err := os.Create(b.options.File)
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not create evidence bundle file")
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	archive := &archiveWriter{tarWriter: tarWriter, modTime: time.Now()}
+
+	if err := b.writeEntries(archive, options); err != nil {
+		return err
+	}
+
+	manifest := &Manifest{
+		NucleiVersion:    config.Version,
+		TemplatesVersion: config.DefaultConfig.TemplateVersion,
+		StartedAt:        b.startedAt,
+		FinishedAt:       archive.modTime,
+		Results:          b.results,
+		Files:            archive.files,
+	}
+	if b.options.Signer != nil {
+		manifest.Signer = b.options.Signer.Identifier()
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not marshal evidence manifest")
+	}
+	if err := archive.writeUnlisted(ManifestFile, manifestData); err != nil {
+		return err
+	}
+	if b.options.Signer != nil {
+		signature, err := b.options.Signer.SignData(manifestData)
+		if err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not sign evidence manifest")
+		}
+		if err := archive.writeUnlisted(SignatureFile, signature); err != nil {
+			return err
+		}
+		if err := archive.writeUnlisted(CertificateFile, b.options.Signer.Certificate()); err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write evidence bundle")
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write evidence bundle")
+	}
+	return nil
+}
+
+// writeEntries writes all evidence entries listed in the manifest
+func (b *Bundle) writeEntries(archive *archiveWriter, options *types.Options) error {
+	if err := archive.writeFile("results.jsonl", b.resultsFile.Name()); err != nil {
+		return err
+	}
+	if err := archive.writeDir("evidence", filepath.Join(b.spoolDir, "evidence")); err != nil {
+		return err
+	}
+
+	for i, screenshot := range sortedKeys(b.screenshots) {
+		name := fmt.Sprintf("screenshots/%05d-%s", i+1, filepath.Base(screenshot))
+		if err := archive.writeFile(name, screenshot); err != nil {
+			return err
+		}
+	}
+
+	if b.options.StoreResponseDir != "" && fileutil.FolderExists(b.options.StoreResponseDir) {
+		if err := archive.writeDir("responses", b.options.StoreResponseDir); err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not add stored responses to evidence bundle")
+		}
+	}
+
+	templates := make([]Template, 0, len(b.templates))
+	for _, key := range sortedKeys(b.templates) {
+		templates = append(templates, b.templates[key])
+	}
+	templatesData, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not marshal templates")
+	}
+	if err := archive.write("templates.json", templatesData); err != nil {
+		return err
+	}
+
+	if options != nil {
+		configData, err := json.MarshalIndent(redactOptions(options), "", "  ")
+		if err != nil {
+			return errorutil.NewWithErr(err).Msgf("could not marshal scan configuration")
+		}
+		if err := archive.write("config.json", configData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveWriter writes entries to a tar archive keeping track of
+// written files and their hashes for the manifest
+type archiveWriter struct {
+	tarWriter *tar.Writer
+	modTime   time.Time
+	files     []File
+}
+
+// write writes given data as a file listed in the manifest
+func (a *archiveWriter) write(name string, data []byte) error {
+	if err := a.writeUnlisted(name, data); err != nil {
+		return err
+	}
+	hash := sha256.Sum256(data)
+	a.files = append(a.files, File{Name: name, Size: int64(len(data)), SHA256: hex.EncodeToString(hash[:])})
+	return nil
+}
+
+// writeFile writes given file from disk as a file listed in the manifest
+func (a *archiveWriter) writeFile(name, filePath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not open %v", filePath)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not stat %v", filePath)
+	}
+	if err := a.tarWriter.WriteHeader(a.header(name, info.Size())); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write %v to evidence bundle", name)
+	}
+	hasher := sha256.New()
+	written, err := io.Copy(a.tarWriter, io.TeeReader(file, hasher))
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write %v to evidence bundle", name)
+	}
+	a.files = append(a.files, File{Name: name, Size: written, SHA256: hex.EncodeToString(hasher.Sum(nil))})
+	return nil
+}
+
+// writeDir writes all files of given directory from disk under
+// given name as files listed in the manifest
+func (a *archiveWriter) writeDir(name, dir string) error {
+	return filepath.WalkDir(dir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relative, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		return a.writeFile(path.Join(name, filepath.ToSlash(relative)), filePath)
+	})
+}
+
+// writeUnlisted writes given data as a file not listed in the manifest
+func (a *archiveWriter) writeUnlisted(name string, data []byte) error {
+	if err := a.tarWriter.WriteHeader(a.header(name, int64(len(data)))); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write %v to evidence bundle", name)
+	}
+	if _, err := a.tarWriter.Write(data); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write %v to evidence bundle", name)
+	}
+	return nil
+}
+
+func (a *archiveWriter) header(name string, size int64) *tar.Header {
+	return &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: a.modTime, Typeflag: tar.TypeReg}
+}
+
+// sanitizeName replaces characters which are not safe in file names
+func sanitizeName(name string) string {
+	sanitized := []rune(name)
+	for i, r := range sanitized {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+			sanitized[i] = '_'
+		}
+	}
+	return string(sanitized)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
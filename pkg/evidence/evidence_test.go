@@ -0,0 +1,168 @@
+package evidence
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates/signer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) *signer.TemplateSigner {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.Nil(t, err)
+	key, err := x509.MarshalECPrivateKey(privateKey)
+	require.Nil(t, err)
+
+	tsigner, err := signer.NewTemplateSigner(
+		pem.EncodeToMemory(&pem.Block{Type: signer.CertType, Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: signer.PrivateKeyType, Bytes: key}),
+	)
+	require.Nil(t, err)
+	return tsigner
+}
+
+// readBundle returns the contents of files of the bundle
+func readBundle(t *testing.T, file string) map[string][]byte {
+	f, err := os.Open(file)
+	require.Nil(t, err)
+	defer f.Close()
+	gzipReader, err := gzip.NewReader(f)
+	require.Nil(t, err)
+
+	files := make(map[string][]byte)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		data, err := io.ReadAll(tarReader)
+		require.Nil(t, err)
+		files[header.Name] = data
+	}
+	return files
+}
+
+// writeBundle writes given files as a bundle
+func writeBundle(t *testing.T, file string, files map[string][]byte) {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, data := range files {
+		require.Nil(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data))}))
+		_, err := tarWriter.Write(data)
+		require.Nil(t, err)
+	}
+	require.Nil(t, tarWriter.Close())
+	require.Nil(t, gzipWriter.Close())
+	require.Nil(t, os.WriteFile(file, buffer.Bytes(), 0644))
+}
+
+func TestBundle(t *testing.T) {
+	dir := t.TempDir()
+	screenshot := filepath.Join(dir, "screenshot.png")
+	require.Nil(t, os.WriteFile(screenshot, []byte("png"), 0644))
+	template := filepath.Join(dir, "template.yaml")
+	require.Nil(t, os.WriteFile(template, []byte("id: test-template"), 0644))
+	responses := filepath.Join(dir, "responses")
+	require.Nil(t, os.MkdirAll(filepath.Join(responses, "http"), 0755))
+	require.Nil(t, os.WriteFile(filepath.Join(responses, "http", "example.com.txt"), []byte("HTTP/1.1 200 OK"), 0644))
+
+	file := filepath.Join(dir, "evidence.tar.gz")
+	bundle, err := New(&Options{File: file, Signer: newTestSigner(t), StoreResponseDir: responses})
+	require.Nil(t, err)
+
+	bundle.AddResult(&output.ResultEvent{TemplateID: "test-template", Host: "example.com", Request: "GET / HTTP/1.1", Response: "HTTP/1.1 200 OK"})
+	bundle.AddScreenshot(screenshot)
+	bundle.AddTemplate("test-template", template, true)
+	options := &types.Options{GitHubToken: "secret-token", CustomHeaders: []string{"Authorization: Bearer secret-token"}}
+	require.Nil(t, options.Vars.Set("password=secret-password"))
+	err = bundle.Write(options)
+	require.Nil(t, err)
+	defer bundle.Close()
+
+	manifest, err := Verify(file)
+	require.Nil(t, err, "could not verify evidence bundle")
+	require.Equal(t, 1, manifest.Results)
+	require.Equal(t, "test", manifest.Signer)
+
+	files := readBundle(t, file)
+	for _, name := range []string{
+		"results.jsonl",
+		"evidence/00001-test-template-request.txt",
+		"evidence/00001-test-template-response.txt",
+		"screenshots/00001-screenshot.png",
+		"responses/http/example.com.txt",
+		"templates.json",
+		"config.json",
+		ManifestFile,
+		SignatureFile,
+		CertificateFile,
+	} {
+		require.Contains(t, files, name)
+	}
+	require.Contains(t, string(files["templates.json"]), "test-template")
+	require.NotContains(t, string(files["config.json"]), "secret-token", "credentials were not redacted")
+	require.NotContains(t, string(files["config.json"]), "secret-password", "vars were not redacted")
+	require.Contains(t, string(files["config.json"]), "password", "var names were not kept")
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := filepath.Join(dir, "tampered.tar.gz")
+		modified := readBundle(t, file)
+		modified["results.jsonl"] = []byte("{}\n")
+		writeBundle(t, tampered, modified)
+
+		_, err := Verify(tampered)
+		require.NotNil(t, err, "tampered evidence bundle was verified")
+	})
+}
+
+func TestBundleSpool(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "evidence.tar.gz")
+	bundle, err := New(&Options{File: file})
+	require.Nil(t, err)
+
+	bundle.AddResult(&output.ResultEvent{TemplateID: "first", Request: "GET / HTTP/1.1"})
+	require.FileExists(t, filepath.Join(bundle.spoolDir, "evidence", "00001-first-request.txt"), "request was not spooled")
+
+	require.Nil(t, bundle.Write(nil))
+	bundle.AddResult(&output.ResultEvent{TemplateID: "second", Response: "HTTP/1.1 200 OK"})
+	require.Nil(t, bundle.Write(nil))
+
+	manifest, err := Verify(file)
+	require.Nil(t, err, "could not verify evidence bundle")
+	require.Equal(t, 2, manifest.Results)
+	files := readBundle(t, file)
+	require.Contains(t, files, "evidence/00002-second-response.txt")
+	require.Len(t, bytes.Split(bytes.TrimSpace(files["results.jsonl"]), []byte("\n")), 2)
+
+	bundle.Close()
+	require.NoDirExists(t, bundle.spoolDir, "spool directory was not removed")
+	bundle.AddResult(&output.ResultEvent{TemplateID: "third"})
+	require.NotNil(t, bundle.Write(nil), "wrote closed evidence bundle")
+}
@@ -0,0 +1,122 @@
+package evidence
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates/signer"
+	errorutil "github.com/projectdiscovery/utils/errors"
+)
+
+// Manifest describes the contents of an evidence bundle
+type Manifest struct {
+	// NucleiVersion is the version of nuclei used for the scan
+	NucleiVersion string `json:"nuclei-version"`
+	// TemplatesVersion is the version of nuclei-templates installed
+	TemplatesVersion string `json:"templates-version,omitempty"`
+	// StartedAt is the time the scan was started
+	StartedAt time.Time `json:"started-at"`
+	// FinishedAt is the time the bundle was written
+	FinishedAt time.Time `json:"finished-at"`
+	// Results is the number of results in the bundle
+	Results int `json:"results"`
+	// Signer is the identifier of the signer of the manifest
+	Signer string `json:"signer,omitempty"`
+	// Files contains all files of the bundle except the manifest,
+	// its signature and signer certificate
+	Files []File `json:"files"`
+}
+
+// File is a file of the evidence bundle
+type File struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Verify verifies the files of an evidence bundle against its manifest and
+// the manifest signature (if signed) using the certificate included in the bundle.
+// It returns the manifest of the bundle on success
+func Verify(file string) (*Manifest, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not open evidence bundle")
+	}
+	defer f.Close()
+
+	gzipReader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not read evidence bundle")
+	}
+	defer gzipReader.Close()
+
+	var manifestData, signature, certificate []byte
+	files := make(map[string]File)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Msgf("could not read evidence bundle")
+		}
+		switch header.Name {
+		case ManifestFile:
+			manifestData, err = io.ReadAll(tarReader)
+		case SignatureFile:
+			signature, err = io.ReadAll(tarReader)
+		case CertificateFile:
+			certificate, err = io.ReadAll(tarReader)
+		default:
+			hasher := sha256.New()
+			var size int64
+			size, err = io.Copy(hasher, tarReader)
+			files[header.Name] = File{Name: header.Name, Size: size, SHA256: hex.EncodeToString(hasher.Sum(nil))}
+		}
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Msgf("could not read %v from evidence bundle", header.Name)
+		}
+	}
+	if manifestData == nil {
+		return nil, errorutil.New("manifest not found in evidence bundle")
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not unmarshal evidence manifest")
+	}
+	for _, expected := range manifest.Files {
+		actual, ok := files[expected.Name]
+		if !ok {
+			return nil, errorutil.New("file %v of manifest not found in evidence bundle", expected.Name)
+		}
+		if actual != expected {
+			return nil, errorutil.New("file %v of evidence bundle does not match manifest", expected.Name)
+		}
+		delete(files, expected.Name)
+	}
+	for name := range files {
+		return nil, errorutil.New("file %v of evidence bundle is not listed in manifest", name)
+	}
+
+	if signature != nil {
+		if certificate == nil {
+			return nil, errorutil.New("signer certificate not found in evidence bundle")
+		}
+		verifier, err := signer.NewTemplateSigVerifier(certificate)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Msgf("could not parse signer certificate")
+		}
+		if !verifier.VerifyData(manifestData, signature) {
+			return nil, errorutil.New("invalid evidence manifest signature")
+		}
+	}
+	return manifest, nil
+}
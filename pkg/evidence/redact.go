@@ -0,0 +1,68 @@
+package evidence
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+const redacted = "[REDACTED]"
+
+// redactedOptions is the scan configuration written to the bundle
+type redactedOptions struct {
+	*types.Options
+	// Vars contains names of custom global vars (values may contain credentials)
+	Vars map[string]string `json:"Vars,omitempty"`
+}
+
+// redactOptions returns a copy of scan options with credentials removed
+// so that scan configuration can be shared with auditors
+func redactOptions(options *types.Options) *redactedOptions {
+	copied := *options
+	for _, value := range []*string{
+		&copied.InteractshToken,
+		&copied.GitHubToken,
+		&copied.GitLabToken,
+		&copied.AwsAccessKey,
+		&copied.AwsSecretKey,
+		&copied.AwsSignerSecretKey,
+		&copied.AwsSignerSessionToken,
+		&copied.AzureClientSecret,
+	} {
+		if *value != "" {
+			*value = redacted
+		}
+	}
+
+	// keep header names only
+	copied.CustomHeaders = nil
+	for _, header := range options.CustomHeaders {
+		name, _, _ := strings.Cut(header, ":")
+		copied.CustomHeaders = append(copied.CustomHeaders, name+": "+redacted)
+	}
+	copied.Proxy = redactURLs(options.Proxy)
+	copied.ProxyHTTP = redactURLs(options.ProxyHTTP)
+	copied.ProxyNetwork = redactURLs(options.ProxyNetwork)
+
+	// keep var names only
+	copied.Vars = goflags.RuntimeMap{}
+	vars := make(map[string]string)
+	for name := range options.Vars.AsMap() {
+		vars[name] = redacted
+	}
+	return &redactedOptions{Options: &copied, Vars: vars}
+}
+
+// redactURLs removes passwords from given urls
+func redactURLs(values []string) []string {
+	var redactedValues []string
+	for _, value := range values {
+		if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+			value = parsed.Redacted()
+		}
+		redactedValues = append(redactedValues, value)
+	}
+	return redactedValues
+}
@@ -70,3 +70,26 @@ func TestConvertInputToType(t *testing.T) {
 		require.Equal(t, test.result, result, "could not get correct result %+v", test)
 	}
 }
+
+func TestParseServiceHints(t *testing.T) {
+	tests := []struct {
+		input    string
+		targets  []string
+		services []string
+	}{
+		{"example.com", []string{"example.com"}, nil},
+		{"example.com:22", []string{"example.com:22"}, nil},
+		{"https://example.com/?a=1,2", []string{"https://example.com/?a=1,2"}, nil},
+		{"10.0.0.1:22,ssh", []string{"10.0.0.1:22"}, []string{"ssh"}},
+		{"10.0.0.1:22,2222,SSH", []string{"10.0.0.1:22", "10.0.0.1:2222"}, []string{"ssh", "2222"}},
+		{"10.0.0.1:22,2222", []string{"10.0.0.1:22", "10.0.0.1:2222"}, []string{"ssh", "2222"}},
+		{"10.0.0.1,3306,mysql,mariadb", []string{"10.0.0.1:3306"}, []string{"mysql", "mariadb"}},
+		{"example.com,redis", []string{"example.com"}, []string{"redis"}},
+	}
+
+	for _, test := range tests {
+		targets, services := ParseServiceHints(test.input)
+		require.Equal(t, test.targets, targets, "could not get correct targets %+v", test)
+		require.Equal(t, test.services, services, "could not get correct services %+v", test)
+	}
+}
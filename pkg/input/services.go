@@ -0,0 +1,100 @@
+package input
+
+import (
+	"net"
+	"strings"
+
+	"github.com/projectdiscovery/utils/ports"
+	sliceutil "github.com/projectdiscovery/utils/slice"
+)
+
+// ParseServiceHints parses a target carrying a port list and/or service hints
+// in the form host[:port][,port...][,service...] (ex: 10.0.0.1:22,2222,ssh).
+//
+// It returns one host:port target per port found (or the bare host if no port
+// was given) along with the lowercased service hints. Hints are also derived
+// from the ports of the list using well-known services (or the port itself
+// if unknown) so that port-only lists are routed as well. Inputs which are
+// URLs or do not contain a list are returned as is.
+func ParseServiceHints(value string) ([]string, []string) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "://") || !strings.Contains(value, ",") {
+		return []string{value}, nil
+	}
+	parts := strings.Split(value, ",")
+
+	host := strings.TrimSpace(parts[0])
+	var portList, services []string
+	if h, p, err := net.SplitHostPort(host); err == nil && ports.IsValid(p) {
+		host = h
+		portList = append(portList, p)
+	}
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "":
+			continue
+		case ports.IsValid(part):
+			portList = append(portList, part)
+		default:
+			services = append(services, strings.ToLower(part))
+		}
+	}
+	if len(portList) == 0 {
+		return []string{host}, services
+	}
+	targets := make([]string, 0, len(portList))
+	for _, port := range portList {
+		targets = append(targets, net.JoinHostPort(host, port))
+
+		hint := ServiceForPort(port)
+		if hint == "" {
+			hint = port
+		}
+		if !sliceutil.Contains(services, hint) {
+			services = append(services, hint)
+		}
+	}
+	return targets, services
+}
+
+// wellKnownServices maps well-known ports to service names
+var wellKnownServices = map[string]string{
+	"21":    "ftp",
+	"22":    "ssh",
+	"23":    "telnet",
+	"25":    "smtp",
+	"53":    "dns",
+	"110":   "pop3",
+	"111":   "rpcbind",
+	"139":   "smb",
+	"143":   "imap",
+	"161":   "snmp",
+	"389":   "ldap",
+	"445":   "smb",
+	"465":   "smtp",
+	"587":   "smtp",
+	"636":   "ldap",
+	"873":   "rsync",
+	"993":   "imap",
+	"995":   "pop3",
+	"1433":  "mssql",
+	"1521":  "oracle",
+	"2049":  "nfs",
+	"2375":  "docker",
+	"3306":  "mysql",
+	"3389":  "rdp",
+	"5432":  "postgres",
+	"5900":  "vnc",
+	"5985":  "winrm",
+	"6379":  "redis",
+	"9200":  "elasticsearch",
+	"11211": "memcached",
+	"27017": "mongodb",
+}
+
+// ServiceForPort returns the well-known service name of given port
+// or an empty string if the port is not known
+func ServiceForPort(port string) string {
+	return wellKnownServices[port]
+}
@@ -38,7 +38,7 @@ func OpenTLS(protocol, address string) (*NetConn, error) {
 		c.ServerName = host
 		config = c
 	}
-	conn, err := protocolstate.Dialer.DialTLSWithConfig(context.TODO(), protocol, address, config)
+	conn, err := protocolstate.DialTLS(context.TODO(), protocolstate.Dialer, protocol, address, config)
 	if err != nil {
 		return nil, err
 	}
@@ -84,6 +84,7 @@ func (p *StatsTicker) Init(hostCount int64, rulesCount int, requestCount int64)
 	p.stats.AddCounter("requests", uint64(0))
 	p.stats.AddCounter("errors", uint64(0))
 	p.stats.AddCounter("matched", uint64(0))
+	p.stats.AddCounter("suppressed", uint64(0))
 	p.stats.AddCounter("total", uint64(requestCount))
 
 	if p.active {
@@ -130,6 +131,11 @@ func (p *StatsTicker) IncrementMatched() {
 	p.stats.IncrementCounter("matched", 1)
 }
 
+// IncrementSuppressed increments the suppressed results counter by 1.
+func (p *StatsTicker) IncrementSuppressed() {
+	p.stats.IncrementCounter("suppressed", 1)
+}
+
 // IncrementErrorsBy increments the error counter by count.
 func (p *StatsTicker) IncrementErrorsBy(count int64) {
 	p.stats.IncrementCounter("errors", int(count))
@@ -182,6 +188,11 @@ func (p *StatsTicker) makePrintCallback() func(stats clistats.StatisticsClient)
 			builder.WriteString(clistats.String(matched))
 		}
 
+		if suppressed, ok := stats.GetCounter("suppressed"); ok && suppressed > 0 {
+			builder.WriteString(" | Suppressed: ")
+			builder.WriteString(clistats.String(suppressed))
+		}
+
 		if errors, ok := stats.GetCounter("errors"); ok && !p.cloud {
 			builder.WriteString(" | Errors: ")
 			builder.WriteString(clistats.String(errors))
@@ -239,6 +250,8 @@ func metricsMap(stats clistats.StatisticsClient) map[string]interface{} {
 	results["hosts"] = clistats.String(hosts)
 	matched, _ := stats.GetCounter("matched")
 	results["matched"] = clistats.String(matched)
+	suppressed, _ := stats.GetCounter("suppressed")
+	results["suppressed"] = clistats.String(suppressed)
 	requests, _ := stats.GetCounter("requests")
 	results["requests"] = clistats.String(requests)
 	total, _ := stats.GetCounter("total")
@@ -0,0 +1,176 @@
+// Package browserprofile implements rotation of user-agent along with the
+// matching browser headers (Accept, Sec-Fetch etc) for http requests.
+package browserprofile
+
+import (
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Mzack9999/gcache"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	fileutil "github.com/projectdiscovery/utils/file"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// RotatePerRequest selects a new profile for every request
+	RotatePerRequest = "request"
+	// RotatePerHost selects a profile once per host and reuses it
+	RotatePerHost = "host"
+
+	// allPresets selects all the builtin profiles
+	allPresets = "all"
+)
+
+// MaxHosts is the maximum number of hosts whose profile is remembered
+// with per host rotation, least recently used hosts are evicted first
+var MaxHosts = 10000
+
+// Profile is a browser profile containing a user-agent
+// and the headers sent by the browser along with it
+type Profile struct {
+	Name      string            `yaml:"name"`
+	UserAgent string            `yaml:"user-agent"`
+	Headers   map[string]string `yaml:"headers"`
+}
+
+// Rotator rotates browser profiles per request or per host
+type Rotator struct {
+	profiles []*Profile
+	perHost  bool
+	mutex    sync.Mutex
+	hosts    gcache.Cache[string, *Profile]
+}
+
+// New creates a new rotator from a list of preset names and/or
+// profile files (yaml list of profiles) with the given rotation mode
+func New(sources []string, rotation string) (*Rotator, error) {
+	rotator := &Rotator{}
+	switch strings.ToLower(rotation) {
+	case "", RotatePerRequest:
+	case RotatePerHost:
+		rotator.perHost = true
+		rotator.hosts = gcache.New[string, *Profile](MaxHosts).LRU().Build()
+	default:
+		return nil, errorutil.New("invalid user-agent rotation %v, supported values are: %v,%v", rotation, RotatePerRequest, RotatePerHost)
+	}
+	for _, source := range sources {
+		profiles, err := loadProfiles(strings.TrimSpace(source))
+		if err != nil {
+			return nil, err
+		}
+		rotator.profiles = append(rotator.profiles, profiles...)
+	}
+	if len(rotator.profiles) == 0 {
+		return nil, errorutil.New("no browser profiles found")
+	}
+	return rotator, nil
+}
+
+// Get returns the browser profile to use for a request to host
+func (r *Rotator) Get(host string) *Profile {
+	if !r.perHost {
+		return r.random()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if profile, err := r.hosts.Get(host); err == nil {
+		return profile
+	}
+	profile := r.random()
+	_ = r.hosts.Set(host, profile)
+	return profile
+}
+
+func (r *Rotator) random() *Profile {
+	return r.profiles[rand.Intn(len(r.profiles))]
+}
+
+// Presets returns the names of builtin presets
+func Presets() []string {
+	names := []string{allPresets}
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+// loadProfiles loads profiles of a preset or a profile file
+func loadProfiles(source string) ([]*Profile, error) {
+	if fileutil.FileExists(source) {
+		return loadProfilesFromFile(source)
+	}
+	name := strings.ToLower(source)
+	if name == allPresets {
+		var all []*Profile
+		for _, preset := range Presets()[1:] {
+			all = append(all, presets[preset]...)
+		}
+		return all, nil
+	}
+	profiles, ok := presets[name]
+	if !ok {
+		return nil, errorutil.New("invalid browser profile %v, supported presets are: %v", source, strings.Join(Presets(), ","))
+	}
+	return profiles, nil
+}
+
+// loadProfilesFromFile loads profiles from a yaml file
+func loadProfilesFromFile(file string) ([]*Profile, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not read browser profiles file %v", file)
+	}
+	var profiles []*Profile
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not unmarshal browser profiles file %v", file)
+	}
+	for i, profile := range profiles {
+		if profile == nil {
+			return nil, errorutil.New("empty browser profile at index %d in %v", i, file)
+		}
+		if profile.UserAgent == "" {
+			return nil, errorutil.New("user-agent cannot be empty in browser profile %v", profile.Name)
+		}
+		// headers are set only if missing hence keys must be canonical
+		headers := make(map[string]string, len(profile.Headers))
+		for k, v := range profile.Headers {
+			headers[http.CanonicalHeaderKey(k)] = v
+		}
+		profile.Headers = headers
+	}
+	return profiles, nil
+}
+
+var defaultRotator *Rotator
+
+// Init initializes the default rotator from options. Rotation
+// is disabled when no browser profiles are configured
+func Init(options *types.Options) error {
+	defaultRotator = nil
+	if len(options.UserAgentProfiles) == 0 {
+		return nil
+	}
+	rotator, err := New(options.UserAgentProfiles, options.UserAgentRotation)
+	if err != nil {
+		return err
+	}
+	defaultRotator = rotator
+	return nil
+}
+
+// Get returns the browser profile to use for a request to host
+// using default rotator. It returns nil if rotation is disabled
+func Get(host string) *Profile {
+	if defaultRotator == nil {
+		return nil
+	}
+	return defaultRotator.Get(host)
+}
@@ -0,0 +1,71 @@
+package browserprofile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotator(t *testing.T) {
+	t.Run("presets", func(t *testing.T) {
+		rotator, err := New([]string{"chrome", "firefox"}, RotatePerRequest)
+		require.Nil(t, err, "could not create rotator")
+		require.Len(t, rotator.profiles, len(presets["chrome"])+len(presets["firefox"]), "could not load presets")
+
+		_, err = New([]string{"netscape"}, RotatePerRequest)
+		require.NotNil(t, err, "loaded invalid preset")
+		_, err = New([]string{"chrome"}, "target")
+		require.NotNil(t, err, "accepted invalid rotation")
+	})
+
+	t.Run("per-host", func(t *testing.T) {
+		rotator, err := New([]string{"all"}, RotatePerHost)
+		require.Nil(t, err, "could not create rotator")
+		profile := rotator.Get("scanme.sh")
+		for i := 0; i < 10; i++ {
+			require.Equal(t, profile, rotator.Get("scanme.sh"), "got different profile for same host")
+		}
+	})
+
+	t.Run("per-host-bounded", func(t *testing.T) {
+		defer func(maxHosts int) { MaxHosts = maxHosts }(MaxHosts)
+		MaxHosts = 2
+
+		rotator, err := New([]string{"all"}, RotatePerHost)
+		require.Nil(t, err, "could not create rotator")
+		for _, host := range []string{"a.scanme.sh", "b.scanme.sh", "c.scanme.sh"} {
+			rotator.Get(host)
+		}
+		require.Equal(t, 2, rotator.hosts.Len(true), "hosts were not bounded")
+	})
+
+	t.Run("file", func(t *testing.T) {
+		profiles := `- name: custom
+  user-agent: custom-agent/1.0
+  headers:
+    sec-fetch-mode: navigate
+`
+		file := filepath.Join(t.TempDir(), "profiles.yaml")
+		require.Nil(t, os.WriteFile(file, []byte(profiles), 0644), "could not write profiles file")
+
+		rotator, err := New([]string{file}, "")
+		require.Nil(t, err, "could not create rotator")
+		profile := rotator.Get("scanme.sh")
+		require.Equal(t, "custom-agent/1.0", profile.UserAgent, "could not get correct user-agent")
+		require.Equal(t, "navigate", profile.Headers["Sec-Fetch-Mode"], "could not get canonical header")
+	})
+
+	t.Run("file-empty-entry", func(t *testing.T) {
+		profiles := `-
+- name: custom
+  user-agent: custom-agent/1.0
+`
+		file := filepath.Join(t.TempDir(), "profiles.yaml")
+		require.Nil(t, os.WriteFile(file, []byte(profiles), 0644), "could not write profiles file")
+
+		_, err := New([]string{file}, "")
+		require.NotNil(t, err, "accepted empty browser profile")
+	})
+}
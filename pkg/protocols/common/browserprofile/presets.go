@@ -0,0 +1,89 @@
+package browserprofile
+
+const (
+	chromeAccept  = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,image/apng,*/*;q=0.8,application/signed-exchange;v=b3;q=0.7"
+	firefoxAccept = "text/html,application/xhtml+xml,application/xml;q=0.9,image/avif,image/webp,*/*;q=0.8"
+	safariAccept  = "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"
+)
+
+// presets contains the builtin browser profiles grouped by preset name
+var presets = map[string][]*Profile{
+	"chrome": {
+		chromium("chrome-windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36", `"Google Chrome";v="129", "Not=A?Brand";v="8", "Chromium";v="129"`, `"Windows"`, false),
+		chromium("chrome-macos", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36", `"Google Chrome";v="129", "Not=A?Brand";v="8", "Chromium";v="129"`, `"macOS"`, false),
+		chromium("chrome-linux", "Mozilla/5.0 (X11; Linux x86_64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36", `"Google Chrome";v="129", "Not=A?Brand";v="8", "Chromium";v="129"`, `"Linux"`, false),
+	},
+	"chrome-android": {
+		chromium("chrome-android", "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Mobile Safari/537.36", `"Google Chrome";v="129", "Not=A?Brand";v="8", "Chromium";v="129"`, `"Android"`, true),
+	},
+	"edge": {
+		chromium("edge-windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/129.0.0.0 Safari/537.36 Edg/129.0.0.0", `"Microsoft Edge";v="129", "Not=A?Brand";v="8", "Chromium";v="129"`, `"Windows"`, false),
+	},
+	"firefox": {
+		firefox("firefox-windows", "Mozilla/5.0 (Windows NT 10.0; Win64; x64; rv:131.0) Gecko/20100101 Firefox/131.0"),
+		firefox("firefox-macos", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10.15; rv:131.0) Gecko/20100101 Firefox/131.0"),
+		firefox("firefox-linux", "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"),
+	},
+	"safari": {
+		safari("safari-macos", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Safari/605.1.15"),
+	},
+	"safari-ios": {
+		safari("safari-ios", "Mozilla/5.0 (iPhone; CPU iPhone OS 18_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.0 Mobile/15E148 Safari/604.1"),
+	},
+}
+
+// chromium returns a profile of a chromium based browser
+func chromium(name, userAgent, brands, platform string, mobile bool) *Profile {
+	isMobile := "?0"
+	if mobile {
+		isMobile = "?1"
+	}
+	return &Profile{
+		Name:      name,
+		UserAgent: userAgent,
+		Headers: map[string]string{
+			"Accept":                    chromeAccept,
+			"Accept-Language":           "en-US,en;q=0.9",
+			"Sec-Ch-Ua":                 brands,
+			"Sec-Ch-Ua-Mobile":          isMobile,
+			"Sec-Ch-Ua-Platform":        platform,
+			"Sec-Fetch-Dest":            "document",
+			"Sec-Fetch-Mode":            "navigate",
+			"Sec-Fetch-Site":            "none",
+			"Sec-Fetch-User":            "?1",
+			"Upgrade-Insecure-Requests": "1",
+		},
+	}
+}
+
+// firefox returns a profile of firefox browser
+func firefox(name, userAgent string) *Profile {
+	return &Profile{
+		Name:      name,
+		UserAgent: userAgent,
+		Headers: map[string]string{
+			"Accept":                    firefoxAccept,
+			"Accept-Language":           "en-US,en;q=0.5",
+			"Sec-Fetch-Dest":            "document",
+			"Sec-Fetch-Mode":            "navigate",
+			"Sec-Fetch-Site":            "none",
+			"Sec-Fetch-User":            "?1",
+			"Upgrade-Insecure-Requests": "1",
+		},
+	}
+}
+
+// safari returns a profile of safari browser
+func safari(name, userAgent string) *Profile {
+	return &Profile{
+		Name:      name,
+		UserAgent: userAgent,
+		Headers: map[string]string{
+			"Accept":          safariAccept,
+			"Accept-Language": "en-US,en;q=0.9",
+			"Sec-Fetch-Dest":  "document",
+			"Sec-Fetch-Mode":  "navigate",
+			"Sec-Fetch-Site":  "none",
+		},
+	}
+}
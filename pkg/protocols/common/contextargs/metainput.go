@@ -15,6 +15,8 @@ type MetaInput struct {
 	Input string `json:"input,omitempty"`
 	// CustomIP to use for connection
 	CustomIP string `json:"customIP,omitempty"`
+	// Services contains optional service hints (ex: ssh,mysql) for the target
+	Services []string `json:"services,omitempty"`
 	// hash of the input
 	hash string `json:"-"`
 }
@@ -58,10 +60,19 @@ func (metaInput *MetaInput) Unmarshal(data string) error {
 }
 
 func (metaInput *MetaInput) Clone() *MetaInput {
-	return &MetaInput{
+	input := &MetaInput{
 		Input:    metaInput.Input,
 		CustomIP: metaInput.CustomIP,
 	}
+	if len(metaInput.Services) > 0 {
+		input.Services = append([]string{}, metaInput.Services...)
+	}
+	return input
+}
+
+// HasServiceHints returns true if the target was given with service hints
+func (metaInput *MetaInput) HasServiceHints() bool {
+	return len(metaInput.Services) > 0
 }
 
 func (metaInput *MetaInput) PrettyPrint() string {
@@ -0,0 +1,58 @@
+package executionstats
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+
+	"github.com/projectdiscovery/fastdialer/fastdialer"
+)
+
+// Error classes of failed requests
+const (
+	ErrorClassTimeout           = "timeout"
+	ErrorClassDNS               = "dns"
+	ErrorClassConnectionRefused = "connection-refused"
+	ErrorClassConnectionReset   = "connection-reset"
+	ErrorClassTLS               = "tls"
+	ErrorClassOther             = "other"
+)
+
+// ErrorClass returns the class of a request error based on the known
+// error types it wraps. Errors which do not wrap any of them (ex: errors
+// flattened to a message) are classified as other
+func ErrorClass(err error) string {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return ErrorClassTimeout
+	case errors.As(err, &dnsErr), errors.Is(err, fastdialer.ResolveHostError), errors.Is(err, fastdialer.NoAddressFoundError):
+		return ErrorClassDNS
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrorClassConnectionRefused
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassConnectionReset
+	case isTLSError(err):
+		return ErrorClassTLS
+	}
+	return ErrorClassOther
+}
+
+// isTLSError returns true if the error is a tls handshake or certificate error
+func isTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var verificationErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &recordErr) || errors.As(err, &alertErr) || errors.As(err, &verificationErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr)
+}
@@ -0,0 +1,202 @@
+// Package executionstats collects per-template execution statistics
+// (requests, errors by class, matched hosts and durations) of a scan.
+package executionstats
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	errorutil "github.com/projectdiscovery/utils/errors"
+)
+
+// maxDurationSamples is the max number of durations kept
+// per template to compute p95 duration (reservoir sampling)
+const maxDurationSamples = 1024
+
+// TemplateStats contains the execution statistics of a template
+type TemplateStats struct {
+	// TemplateID is the id of the template
+	TemplateID string `json:"template-id"`
+	// Requests is the number of requests sent by the template
+	Requests uint64 `json:"requests"`
+	// Errors is the number of failed requests by error class
+	Errors map[string]uint64 `json:"errors,omitempty"`
+	// Executions is the number of targets the template was executed on
+	Executions uint64 `json:"executions"`
+	// HostsMatched is the number of hosts with at least one match
+	HostsMatched int `json:"hosts-matched"`
+	// TotalDuration is the total execution duration (total-duration-ms when marshalled)
+	TotalDuration time.Duration `json:"-"`
+	// P95Duration is the 95th percentile of the execution duration on a target
+	// (p95-duration-ms when marshalled)
+	P95Duration time.Duration `json:"-"`
+}
+
+// MarshalJSON marshals the template stats with durations in milliseconds
+func (s *TemplateStats) MarshalJSON() ([]byte, error) {
+	type templateStats TemplateStats
+	return json.Marshal(&struct {
+		*templateStats
+		TotalDurationMs int64 `json:"total-duration-ms"`
+		P95DurationMs   int64 `json:"p95-duration-ms"`
+	}{
+		templateStats:   (*templateStats)(s),
+		TotalDurationMs: s.TotalDuration.Milliseconds(),
+		P95DurationMs:   s.P95Duration.Milliseconds(),
+	})
+}
+
+// Collector collects execution statistics of templates.
+// It is safe for concurrent use
+type Collector struct {
+	mu        sync.RWMutex
+	templates map[string]*templateStats
+}
+
+type templateStats struct {
+	mu         sync.Mutex
+	requests   uint64
+	errors     map[string]uint64
+	executions uint64
+	hosts      map[string]struct{}
+	total      time.Duration
+	durations  []time.Duration
+}
+
+// New creates a new execution statistics collector
+func New() *Collector {
+	return &Collector{templates: make(map[string]*templateStats)}
+}
+
+// get returns the stats of a template creating it if missing
+func (c *Collector) get(templateID string) *templateStats {
+	c.mu.RLock()
+	stats, ok := c.templates[templateID]
+	c.mu.RUnlock()
+	if ok {
+		return stats
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stats, ok = c.templates[templateID]; !ok {
+		stats = &templateStats{errors: make(map[string]uint64), hosts: make(map[string]struct{})}
+		c.templates[templateID] = stats
+	}
+	return stats
+}
+
+// RecordRequest records a request sent by the template along with its error (if any)
+func (c *Collector) RecordRequest(templateID string, err error) {
+	stats := c.get(templateID)
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.requests++
+	if err != nil {
+		stats.errors[ErrorClass(err)]++
+	}
+}
+
+// RecordExecution records the execution of the template on a host
+func (c *Collector) RecordExecution(templateID, host string, duration time.Duration, matched bool) {
+	stats := c.get(templateID)
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	stats.executions++
+	stats.total += duration
+	if matched {
+		stats.hosts[host] = struct{}{}
+	}
+	if len(stats.durations) < maxDurationSamples {
+		stats.durations = append(stats.durations, duration)
+	} else if i := rand.Int63n(int64(stats.executions)); i < maxDurationSamples {
+		stats.durations[i] = duration
+	}
+}
+
+// Stats returns the statistics of all templates sorted by total duration
+func (c *Collector) Stats() []*TemplateStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	results := make([]*TemplateStats, 0, len(c.templates))
+	for templateID, stats := range c.templates {
+		results = append(results, stats.snapshot(templateID))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].TotalDuration == results[j].TotalDuration {
+			return results[i].TemplateID < results[j].TemplateID
+		}
+		return results[i].TotalDuration > results[j].TotalDuration
+	})
+	return results
+}
+
+// WriteJSON writes the statistics of all templates to a json file
+func (c *Collector) WriteJSON(file string) error {
+	data, err := json.MarshalIndent(c.Stats(), "", "  ")
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not marshal execution stats")
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return errorutil.NewWithErr(err).Msgf("could not write execution stats to %v", file)
+	}
+	return nil
+}
+
+// snapshot returns a copy of the template stats
+func (s *templateStats) snapshot(templateID string) *TemplateStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &TemplateStats{
+		TemplateID:    templateID,
+		Requests:      s.requests,
+		Executions:    s.executions,
+		HostsMatched:  len(s.hosts),
+		TotalDuration: s.total,
+	}
+	if len(s.errors) > 0 {
+		result.Errors = make(map[string]uint64, len(s.errors))
+		for class, count := range s.errors {
+			result.Errors[class] = count
+		}
+	}
+	if len(s.durations) > 0 {
+		durations := make([]time.Duration, len(s.durations))
+		copy(durations, s.durations)
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		// nearest-rank percentile
+		result.P95Duration = durations[(len(durations)*95+99)/100-1]
+	}
+	return result
+}
+
+// Writer returns an output writer which records requests of given templates
+// before forwarding them to the given writer. Requests of clustered templates
+// are shared by all the templates of the cluster hence recorded for each of them
+func (c *Collector) Writer(writer output.Writer, templateIDs ...string) output.Writer {
+	return &statsWriter{Writer: writer, templateIDs: templateIDs, collector: c}
+}
+
+// statsWriter is an output writer recording requests of templates
+type statsWriter struct {
+	output.Writer
+	templateIDs []string
+	collector   *Collector
+}
+
+// Request records the request and writes it to the underlying writer
+func (w *statsWriter) Request(templatePath, input, requestType string, err error) {
+	for _, templateID := range w.templateIDs {
+		w.collector.RecordRequest(templateID, err)
+	}
+	w.Writer.Request(templatePath, input, requestType, err)
+}
@@ -0,0 +1,93 @@
+package executionstats
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/fastdialer/fastdialer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	collector := New()
+	collector.RecordRequest("tech-detect", nil)
+	collector.RecordRequest("tech-detect", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)})
+	collector.RecordRequest("tech-detect", &net.DNSError{Err: "no such host", Name: "unknown.local", IsNotFound: true})
+	for i := 1; i <= 100; i++ {
+		collector.RecordExecution("tech-detect", "scanme.sh", time.Duration(i)*time.Millisecond, i%2 == 0)
+	}
+	collector.RecordExecution("dns-saas", "scanme.sh", time.Millisecond, false)
+
+	stats := collector.Stats()
+	require.Len(t, stats, 2, "could not get stats of all templates")
+
+	got := stats[0]
+	require.Equal(t, "tech-detect", got.TemplateID, "could not sort stats by duration")
+	require.Equal(t, uint64(3), got.Requests, "could not get correct requests")
+	require.Equal(t, map[string]uint64{ErrorClassConnectionRefused: 1, ErrorClassDNS: 1}, got.Errors, "could not get correct errors")
+	require.Equal(t, uint64(100), got.Executions, "could not get correct executions")
+	require.Equal(t, 1, got.HostsMatched, "could not get correct matched hosts")
+	require.Equal(t, 5050*time.Millisecond, got.TotalDuration, "could not get correct total duration")
+	require.Equal(t, 95*time.Millisecond, got.P95Duration, "could not get correct p95 duration")
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{context.DeadlineExceeded, ErrorClassTimeout},
+		{fmt.Errorf("could not do request: %w", os.ErrDeadlineExceeded), ErrorClassTimeout},
+		{&net.DNSError{Err: "no such host", Name: "unknown.local", IsNotFound: true}, ErrorClassDNS},
+		{fmt.Errorf("dial: %w", fastdialer.ResolveHostError), ErrorClassDNS},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, ErrorClassConnectionRefused},
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), ErrorClassConnectionReset},
+		{fmt.Errorf("could not read response: %w", io.ErrUnexpectedEOF), ErrorClassConnectionReset},
+		{fmt.Errorf("handshake: %w", x509.UnknownAuthorityError{}), ErrorClassTLS},
+		// messages containing class keywords are not classified by substring
+		{errors.New("could not parse tls.example.com/geoip response"), ErrorClassOther},
+		{errors.New("unexpected value for key eof-marker"), ErrorClassOther},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, ErrorClass(test.err), "could not get correct class for %v", test.err)
+	}
+}
+
+func TestTemplateStatsJSON(t *testing.T) {
+	data, err := json.Marshal(&TemplateStats{TemplateID: "tech-detect", TotalDuration: 1500 * time.Millisecond, P95Duration: 20 * time.Millisecond})
+	require.Nil(t, err, "could not marshal stats")
+
+	var got map[string]interface{}
+	require.Nil(t, json.Unmarshal(data, &got), "could not unmarshal stats")
+	require.Equal(t, "tech-detect", got["template-id"], "could not get template id")
+	require.Equal(t, float64(1500), got["total-duration-ms"], "could not get total duration in ms")
+	require.Equal(t, float64(20), got["p95-duration-ms"], "could not get p95 duration in ms")
+}
+
+func TestClusteredWriter(t *testing.T) {
+	collector := New()
+	writer := collector.Writer(&testWriter{}, "tech-detect", "favicon-detect")
+	writer.Request("", "scanme.sh", "http", nil)
+
+	for _, stats := range collector.Stats() {
+		require.Equal(t, uint64(1), stats.Requests, "could not record request for %v", stats.TemplateID)
+	}
+	require.Len(t, collector.Stats(), 2, "could not record requests for all templates")
+}
+
+// testWriter is an output writer discarding requests
+type testWriter struct {
+	output.Writer
+}
+
+func (w *testWriter) Request(templatePath, input, requestType string, err error) {}
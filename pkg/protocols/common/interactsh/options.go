@@ -7,6 +7,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/progress"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
+	"github.com/projectdiscovery/nuclei/v3/pkg/suppression"
 	"github.com/projectdiscovery/retryablehttp-go"
 )
 
@@ -33,6 +34,8 @@ type Options struct {
 	IssuesClient reporting.Client
 	// Progress is the nuclei progress bar implementation.
 	Progress progress.Progress
+	// Suppressor drops results matching false-positive suppression rules
+	Suppressor *suppression.Suppressor
 	// Debug specifies whether debugging output should be shown for interactsh-client
 	Debug bool
 	// DebugRequest outputs interaction request
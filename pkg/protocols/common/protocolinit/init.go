@@ -4,6 +4,7 @@ import (
 	"github.com/corpix/uarand"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/js/compiler"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/browserprofile"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/dns/dnsclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
@@ -38,6 +39,11 @@ func Init(options *types.Options) error {
 	if err := compiler.Init(options); err != nil {
 		return err
 	}
+	// profiles are validated with options hence initialized after
+	// client pools so that an invalid profile does not leave them unset
+	if err := browserprofile.Init(options); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -0,0 +1,83 @@
+package protocolstate
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	"github.com/projectdiscovery/fastdialer/fastdialer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	iputil "github.com/projectdiscovery/utils/ip"
+)
+
+var (
+	// ErrProxyBypassDenied is returned when a request cannot be sent through proxy and proxy fail-closed is enabled
+	ErrProxyBypassDenied = errorutil.NewWithFmt("%v requests cannot be sent through proxy and -proxy-fail-closed is enabled")
+	// proxyFailClosed means requests which cannot be sent through proxy are denied
+	proxyFailClosed bool
+	// sniName is the custom sni name used for tls connections dialed through proxy
+	sniName string
+)
+
+// IsDialerProxied returns true if connections made using Dialer
+// are sent through the socks5 proxy of non-http traffic
+func IsDialerProxied() bool {
+	return strings.HasPrefix(types.NetworkProxyURL, "socks5://")
+}
+
+// IsHTTPProxied returns true if http traffic is sent through a proxy
+// either the http proxy or the socks5 proxy of non-http traffic
+func IsHTTPProxied() bool {
+	return types.ProxyURL != "" || types.ProxySocksURL != "" || IsDialerProxied()
+}
+
+// IsProxyConfigured returns true if any proxy is configured
+func IsProxyConfigured() bool {
+	return types.ProxyURL != "" || types.ProxySocksURL != "" || types.NetworkProxyURL != ""
+}
+
+// ValidateProxyBypass returns an error if requests of given protocol would be sent
+// directly instead of through configured proxy and proxy fail-closed is enabled.
+// proxied should be true if requests of protocol are sent through the proxy
+func ValidateProxyBypass(protocol string, proxied bool) error {
+	if !proxyFailClosed || !IsProxyConfigured() || proxied {
+		return nil
+	}
+	return ErrProxyBypassDenied.Msgf(protocol)
+}
+
+// DialTLS dials a tls connection to address using given dialer. fastdialer only sends
+// plain connections through the socks5 proxy hence when Dialer is proxied the connection
+// is dialed through the proxy and upgraded to tls here
+func DialTLS(ctx context.Context, dialer *fastdialer.Dialer, network, address string, config *tls.Config) (net.Conn, error) {
+	if !IsDialerProxied() {
+		if config == nil {
+			return dialer.DialTLS(ctx, network, address)
+		}
+		return dialer.DialTLSWithConfig(ctx, network, address, config)
+	}
+	if config == nil {
+		config = &tls.Config{Renegotiation: tls.RenegotiateOnceAsClient, InsecureSkipVerify: true, MinVersion: tls.VersionTLS10}
+	} else {
+		config = config.Clone()
+	}
+	if sniName != "" {
+		config.ServerName = sniName
+	} else if config.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil && !iputil.IsIP(host) {
+			config.ServerName = host
+		}
+	}
+	conn, err := dialer.Dial(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
@@ -0,0 +1,120 @@
+package protocolstate
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/projectdiscovery/fastdialer/fastdialer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/proxy"
+)
+
+func TestValidateProxyBypass(t *testing.T) {
+	defer func() {
+		proxyFailClosed = false
+		types.ProxyURL, types.ProxySocksURL, types.NetworkProxyURL = "", "", ""
+	}()
+
+	tests := []struct {
+		name       string
+		failClosed bool
+		httpProxy  string
+		proxied    bool
+		wantErr    bool
+	}{
+		{"fail-open", false, "http://127.0.0.1:8080", false, false},
+		{"no-proxy", true, "", false, false},
+		{"proxied", true, "http://127.0.0.1:8080", true, false},
+		{"not-proxied", true, "http://127.0.0.1:8080", false, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			proxyFailClosed = test.failClosed
+			types.ProxyURL = test.httpProxy
+			err := ValidateProxyBypass("dns", test.proxied)
+			if test.wantErr {
+				require.NotNil(t, err, "could not deny request bypassing proxy")
+			} else {
+				require.Nil(t, err, "denied request")
+			}
+		})
+	}
+}
+
+// newTestSocks5Server starts a socks5 server (without authentication) which
+// only supports connect command and counts proxied connections
+func newTestSocks5Server(t *testing.T, connections *int32) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				greeting := make([]byte, 3)
+				if _, err := io.ReadFull(conn, greeting); err != nil {
+					return
+				}
+				_, _ = conn.Write([]byte{0x05, 0x00})
+
+				// version, command, reserved, address type (ipv4), address, port
+				request := make([]byte, 10)
+				if _, err := io.ReadFull(conn, request); err != nil || request[3] != 0x01 {
+					return
+				}
+				address := net.JoinHostPort(net.IP(request[4:8]).String(), strconv.Itoa(int(binary.BigEndian.Uint16(request[8:10]))))
+				target, err := net.Dial("tcp", address)
+				if err != nil {
+					return
+				}
+				defer target.Close()
+				atomic.AddInt32(connections, 1)
+				_, _ = conn.Write([]byte{0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0})
+
+				go func() { _, _ = io.Copy(target, conn) }()
+				_, _ = io.Copy(conn, target)
+			}(conn)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestDialTLSThroughProxy(t *testing.T) {
+	defer func() {
+		types.NetworkProxyURL = ""
+	}()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var connections int32
+	types.NetworkProxyURL = "socks5://" + newTestSocks5Server(t, &connections)
+	proxyURL, err := url.Parse(types.NetworkProxyURL)
+	require.Nil(t, err)
+	proxyDialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+	require.Nil(t, err)
+
+	opts := fastdialer.DefaultOptions
+	opts.ProxyDialer = &proxyDialer
+	dialer, err := fastdialer.NewDialer(opts)
+	require.Nil(t, err)
+	defer dialer.Close()
+
+	conn, err := DialTLS(context.Background(), dialer, "tcp", server.Listener.Addr().String(), nil)
+	require.Nil(t, err, "could not dial tls through proxy")
+	_ = conn.Close()
+	require.Equal(t, int32(1), atomic.LoadInt32(&connections), "tls connection was not sent through proxy")
+}
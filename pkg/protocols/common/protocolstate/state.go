@@ -24,6 +24,8 @@ func Init(options *types.Options) error {
 		return nil
 	}
 	lfaAllowed = options.AllowLocalFileAccess
+	proxyFailClosed = options.ProxyFailClosed
+	sniName = options.SNI
 	opts := fastdialer.DefaultOptions
 	if options.DialerTimeout > 0 {
 		opts.DialerTimeout = options.DialerTimeout
@@ -93,8 +95,8 @@ func Init(options *types.Options) error {
 			},
 		}
 	}
-	if types.ProxySocksURL != "" {
-		proxyURL, err := url.Parse(types.ProxySocksURL)
+	if IsDialerProxied() {
+		proxyURL, err := url.Parse(types.NetworkProxyURL)
 		if err != nil {
 			return err
 		}
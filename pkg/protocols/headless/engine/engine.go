@@ -3,6 +3,7 @@ package engine
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/go-rod/rod/lib/launcher/flags"
 	"github.com/pkg/errors"
 
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	fileutil "github.com/projectdiscovery/utils/file"
 	osutils "github.com/projectdiscovery/utils/os"
@@ -73,8 +76,20 @@ func New(options *types.Options) (*Browser, error) {
 	} else {
 		chromeLauncher = chromeLauncher.Headless(true)
 	}
-	if types.ProxyURL != "" {
-		chromeLauncher = chromeLauncher.Proxy(types.ProxyURL)
+	if types.NetworkProxyURL != "" {
+		proxyURL, err := url.Parse(types.NetworkProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse proxy")
+		}
+		if proxyURL.User != nil {
+			// chrome does not support proxy credentials
+			if options.ProxyFailClosed {
+				return nil, protocolstate.ErrProxyBypassDenied.Msgf("headless")
+			}
+			gologger.Warning().Msgf("Headless browser does not support authenticated proxies, headless requests will not be proxied")
+		} else {
+			chromeLauncher = chromeLauncher.Proxy(proxyURL.String())
+		}
 	}
 
 	for k, v := range options.ParseHeadlessOptionalArguments() {
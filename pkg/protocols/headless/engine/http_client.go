@@ -56,14 +56,14 @@ func newHttpClient(options *types.Options) (*http.Client, error) {
 		MaxConnsPerHost:     500,
 		TLSClientConfig:     tlsConfig,
 	}
-	if types.ProxyURL != "" {
-		if proxyURL, err := url.Parse(types.ProxyURL); err == nil {
+	if types.NetworkProxyURL != "" && !protocolstate.IsDialerProxied() {
+		if proxyURL, err := url.Parse(types.NetworkProxyURL); err == nil {
 			transport.Proxy = http.ProxyURL(proxyURL)
 		}
-	} else if types.ProxySocksURL != "" {
-		socksURL, proxyErr := url.Parse(types.ProxySocksURL)
+	} else if types.NetworkProxyURL != "" {
+		socksURL, proxyErr := url.Parse(types.NetworkProxyURL)
 		if proxyErr != nil {
-			return nil, err
+			return nil, proxyErr
 		}
 		dialer, err := proxy.FromURL(socksURL, proxy.Direct)
 		if err != nil {
@@ -28,6 +28,7 @@ type Page struct {
 	mutex          *sync.RWMutex
 	History        []HistoryData
 	InteractshURLs []string
+	Screenshots    []string
 	payloads       map[string]interface{}
 }
 
@@ -437,6 +437,9 @@ func (p *Page) Screenshot(act *Action, out map[string]string) error {
 		return errors.Wrap(err, "could not write screenshot")
 	}
 	gologger.Info().Msgf("Screenshot successfully saved at %v\n", filePath)
+	p.mutex.Lock()
+	p.Screenshots = append(p.Screenshots, filePath)
+	p.mutex.Unlock()
 	return nil
 }
 
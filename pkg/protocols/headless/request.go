@@ -150,6 +150,10 @@ func (request *Request) executeRequestWithPayloads(input *contextargs.Context, p
 	}
 	defer page.Close()
 
+	for _, screenshot := range page.Screenshots {
+		request.options.Evidence.AddScreenshot(screenshot)
+	}
+
 	reqLog := instance.GetRequestLog()
 	navigatedURL := request.getLastNavigationURLWithLog(reqLog) // also known as matchedURL if there is a match
 
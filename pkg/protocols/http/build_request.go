@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/browserprofile"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/expressions"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
@@ -380,8 +381,21 @@ func (r *requestGenerator) fillRequest(req *retryablehttp.Request, values map[st
 		}
 		req.Body = bodyReader
 	}
+	var profile *browserprofile.Profile
 	if !r.request.Unsafe {
-		httputil.SetHeader(req, "User-Agent", uarand.GetRandom())
+		if profile = browserprofile.Get(req.URL.Host); profile != nil {
+			httputil.SetHeader(req, "User-Agent", profile.UserAgent)
+		} else {
+			httputil.SetHeader(req, "User-Agent", uarand.GetRandom())
+		}
+	}
+
+	// browser profile headers are only set if not set by the template and
+	// take precedence over defaults to match the profile user-agent
+	if profile != nil {
+		for k, v := range profile.Headers {
+			httputil.SetHeader(req, k, v)
+		}
 	}
 
 	// Only set these headers on non-raw requests
@@ -9,10 +9,12 @@ import (
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/browserprofile"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
 	"github.com/projectdiscovery/nuclei/v3/pkg/testutils"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 )
 
 func TestMakeRequestFromModal(t *testing.T) {
@@ -217,3 +219,38 @@ func areUnique(elements []string) bool {
 	}
 	return true
 }
+
+func TestMakeRequestWithBrowserProfile(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	require.Nil(t, browserprofile.Init(&types.Options{UserAgentProfiles: []string{"chrome"}}), "could not init browser profiles")
+	defer func() {
+		_ = browserprofile.Init(&types.Options{})
+	}()
+
+	templateID := "testing-http"
+	request := &Request{
+		ID:      templateID,
+		Name:    "testing",
+		Path:    []string{"{{BaseURL}}"},
+		Method:  HTTPMethodTypeHolder{MethodType: HTTPGet},
+		Headers: map[string]string{"Sec-Fetch-Mode": "cors"},
+	}
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	err := request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile http request")
+
+	generator := request.newGenerator(false)
+	inputData, payloads, _ := generator.nextValue()
+	req, err := generator.Make(context.Background(), contextargs.NewWithInput("https://example.com"), inputData, payloads, map[string]interface{}{})
+	require.Nil(t, err, "could not make http request")
+	require.Contains(t, req.request.Header.Get("User-Agent"), "Chrome/", "could not get profile user-agent")
+	require.Contains(t, req.request.Header.Get("Accept"), "text/html", "could not get profile accept header")
+	require.Equal(t, "en-US,en;q=0.9", req.request.Header.Get("Accept-Language"), "could not get profile accept-language header")
+	require.Equal(t, "cors", req.request.Header.Get("Sec-Fetch-Mode"), "template header was replaced")
+	require.NotEmpty(t, req.request.Header.Get("Sec-Fetch-Dest"), "could not get profile header")
+}
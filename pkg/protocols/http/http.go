@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
 
 	json "github.com/json-iterator/go"
 	"github.com/pkg/errors"
@@ -132,6 +133,8 @@ type Request struct {
 	generator         *generators.PayloadGenerator // optional, only enabled when using payloads
 	httpClient        *retryablehttp.Client
 	rawhttpClient     *rawhttp.Client
+	// negotiationWarning logs unsupported ntlm/negotiate auth once per request
+	negotiationWarning *sync.Once
 
 	// description: |
 	//   SelfContained specifies if the request is self-contained.
@@ -285,6 +288,7 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 	request.customHeaders = make(map[string]string)
 	request.httpClient = client
 	request.options = options
+	request.negotiationWarning = &sync.Once{}
 	for _, option := range request.options.Options.CustomHeaders {
 		parts := strings.SplitN(option, ":", 2)
 		if len(parts) != 2 {
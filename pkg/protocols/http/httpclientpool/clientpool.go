@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Azure/go-ntlmssp"
 	"github.com/pkg/errors"
 	"golang.org/x/net/proxy"
 	"golang.org/x/net/publicsuffix"
@@ -101,6 +102,8 @@ type Configuration struct {
 	RedirectFlow RedirectFlow
 	// Connection defines custom connection configuration
 	Connection *ConnectionConfiguration
+	// NTLMAuth enables ntlm / negotiate handshake for requests carrying credentials
+	NTLMAuth bool
 }
 
 // Hash returns the hash of the configuration to allow client pooling
@@ -119,13 +122,15 @@ func (c *Configuration) Hash() string {
 	builder.WriteString(strconv.FormatBool(c.DisableCookie))
 	builder.WriteString("c")
 	builder.WriteString(strconv.FormatBool(c.Connection != nil))
+	builder.WriteString("a")
+	builder.WriteString(strconv.FormatBool(c.NTLMAuth))
 	hash := builder.String()
 	return hash
 }
 
 // HasStandardOptions checks whether the configuration requires custom settings
 func (c *Configuration) HasStandardOptions() bool {
-	return c.Threads == 0 && c.MaxRedirects == 0 && c.RedirectFlow == DontFollowRedirect && c.DisableCookie && c.Connection == nil && !c.NoTimeout
+	return c.Threads == 0 && c.MaxRedirects == 0 && c.RedirectFlow == DontFollowRedirect && c.DisableCookie && c.Connection == nil && !c.NoTimeout && !c.NTLMAuth
 }
 
 // GetRawHTTP returns the rawhttp request client
@@ -136,6 +141,9 @@ func GetRawHTTP(options *types.Options) *rawhttp.Client {
 			rawHttpOptions.Proxy = types.ProxyURL
 		} else if types.ProxySocksURL != "" {
 			rawHttpOptions.Proxy = types.ProxySocksURL
+		} else if protocolstate.IsDialerProxied() {
+			// fastdialer does not send tls connections through proxy
+			rawHttpOptions.Proxy = types.NetworkProxyURL
 		} else if Dialer != nil {
 			rawHttpOptions.FastDialer = Dialer
 		}
@@ -207,6 +215,10 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 	if configuration.Connection != nil {
 		disableKeepAlives = configuration.Connection.DisableKeepAlive
 	}
+	// ntlm handshake is connection oriented and requires keep-alive
+	if configuration.NTLMAuth {
+		disableKeepAlives = false
+	}
 
 	// Set the base TLS configuration definition
 	tlsConfig := &tls.Config{
@@ -229,6 +241,10 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 		ForceAttemptHTTP2: options.ForceAttemptHTTP2,
 		DialContext:       Dialer.Dial,
 		DialTLSContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if protocolstate.IsDialerProxied() {
+				// tls impersonation is not supported through socks5 proxy
+				return protocolstate.DialTLS(ctx, Dialer, network, addr, tlsConfig)
+			}
 			if options.TlsImpersonate {
 				return Dialer.DialTLSWithConfigImpersonate(ctx, network, addr, tlsConfig, impersonate.Random, nil)
 			}
@@ -248,6 +264,11 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 		if proxyURL, err := url.Parse(types.ProxyURL); err == nil {
 			transport.Proxy = http.ProxyURL(proxyURL)
 		}
+		if protocolstate.IsDialerProxied() {
+			// connect to http proxy directly instead of through the socks5 proxy of non-http traffic
+			directDialer := &net.Dialer{Timeout: options.DialerTimeout, KeepAlive: options.DialerKeepAlive}
+			transport.DialContext = directDialer.DialContext
+		}
 	} else if types.ProxySocksURL != "" {
 		socksURL, proxyErr := url.Parse(types.ProxySocksURL)
 		if proxyErr != nil {
@@ -283,8 +304,14 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 		}
 	}
 
+	var roundTripper http.RoundTripper = transport
+	if configuration.NTLMAuth {
+		// negotiator converts basic auth credentials to ntlm / negotiate handshake
+		roundTripper = ntlmssp.Negotiator{RoundTripper: transport}
+	}
+
 	httpclient := &http.Client{
-		Transport:     transport,
+		Transport:     roundTripper,
 		CheckRedirect: makeCheckRedirectFunc(redirectFlow, maxRedirects),
 	}
 	if !configuration.NoTimeout {
@@ -305,6 +332,57 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 	return client, nil
 }
 
+// GetTokenClient returns a http client for fetching credentials (ex: oauth2 tokens).
+// Unlike the scanning clients it verifies tls certificates so that secrets are not
+// sent to an impersonated endpoint while still honouring the configured proxy
+func GetTokenClient(options *types.Options) (*http.Client, error) {
+	if Dialer == nil {
+		Dialer = protocolstate.Dialer
+	}
+	if Dialer == nil {
+		return nil, errors.New("dialer is not initialized")
+	}
+
+	// tls is established by transport over DialContext hence connections
+	// dialed through the socks5 proxy of non-http traffic are verified as well
+	transport := &http.Transport{
+		DialContext:       Dialer.Dial,
+		TLSClientConfig:   &tls.Config{MinVersion: tls.VersionTLS12},
+		ForceAttemptHTTP2: true,
+	}
+
+	if types.ProxyURL != "" {
+		proxyURL, err := url.Parse(types.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+		if protocolstate.IsDialerProxied() {
+			// connect to http proxy directly instead of through the socks5 proxy of non-http traffic
+			directDialer := &net.Dialer{Timeout: options.DialerTimeout, KeepAlive: options.DialerKeepAlive}
+			transport.DialContext = directDialer.DialContext
+		}
+	} else if types.ProxySocksURL != "" {
+		socksURL, err := url.Parse(types.ProxySocksURL)
+		if err != nil {
+			return nil, err
+		}
+		dialer, err := proxy.FromURL(socksURL, proxy.Direct)
+		if err != nil {
+			return nil, err
+		}
+		dc := dialer.(interface {
+			DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+		})
+		transport.DialContext = dc.DialContext
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(options.Timeout) * time.Second,
+	}, nil
+}
+
 type RedirectFlow uint8
 
 const (
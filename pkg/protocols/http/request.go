@@ -20,6 +20,7 @@ import (
 
 	"github.com/projectdiscovery/fastdialer/fastdialer"
 	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider/authx"
 	"github.com/projectdiscovery/nuclei/v3/pkg/operators"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
@@ -30,6 +31,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/helpers/eventcreator"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/helpers/responsehighlighter"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/tostring"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/signer"
@@ -327,6 +329,10 @@ func (request *Request) executeFuzzingRule(input *contextargs.Context, previous
 
 // ExecuteWithResults executes the final request on a URL
 func (request *Request) ExecuteWithResults(input *contextargs.Context, dynamicValues, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
+	// pipelined requests are always sent directly
+	if err := protocolstate.ValidateProxyBypass(request.Type().String(), protocolstate.IsHTTPProxied() && !request.Pipeline); err != nil {
+		return err
+	}
 	if request.Pipeline || request.Race && request.RaceNumberRequests > 0 || request.Threads > 0 {
 		variablesMap := request.options.Variables.Evaluate(generators.MergeMaps(dynamicValues, previous))
 		dynamicValues = generators.MergeMaps(variablesMap, dynamicValues, request.options.Constants)
@@ -471,6 +477,7 @@ var errStopExecution = errors.New("stop execution due to unresolved variables")
 // executeRequest executes the actual generated request and returns error if occurred
 func (request *Request) executeRequest(input *contextargs.Context, generatedRequest *generatedRequest, previousEvent output.InternalEvent, hasInteractMatchers bool, callback protocols.OutputEventCallback, requestCount int) error {
 	request.setCustomHeaders(generatedRequest)
+	requiresNegotiation := request.applyAuthStrategy(generatedRequest)
 
 	// Try to evaluate any payloads before replacement
 	finalMap := generators.MergeMaps(generatedRequest.dynamicValues, generatedRequest.meta)
@@ -611,9 +618,16 @@ func (request *Request) executeRequest(input *contextargs.Context, generatedRequ
 			}
 
 			httpclient := request.httpClient
-			if input.CookieJar != nil {
+			if input.CookieJar != nil || requiresNegotiation {
 				connConfiguration := request.connConfiguration
-				connConfiguration.Connection.SetCookieJar(input.CookieJar)
+				if requiresNegotiation {
+					ntlmConfiguration := *connConfiguration
+					ntlmConfiguration.NTLMAuth = true
+					connConfiguration = &ntlmConfiguration
+				}
+				if input.CookieJar != nil {
+					connConfiguration.Connection.SetCookieJar(input.CookieJar)
+				}
 				client, err := httpclientpool.Get(request.options.Options, connConfiguration)
 				if err != nil {
 					return errors.Wrap(err, "could not get http client")
@@ -840,14 +854,20 @@ func (request *Request) handleSignature(generatedRequest *generatedRequest) erro
 		var awsSigner signer.Signer
 		allvars := generators.MergeMaps(request.options.Options.Vars.AsMap(), generatedRequest.dynamicValues)
 		awsopts := signer.AWSOptions{
-			AwsID:          types.ToString(allvars["aws-id"]),
-			AwsSecretToken: types.ToString(allvars["aws-secret"]),
+			AwsID:           types.ToString(allvars["aws-id"]),
+			AwsSecretToken:  types.ToString(allvars["aws-secret"]),
+			AwsSessionToken: types.ToString(allvars["aws-session-token"]),
 		}
 		awsSigner, err := signerpool.Get(request.options.Options, &signerpool.Configuration{SignerArgs: &awsopts})
 		if err != nil {
 			return err
 		}
-		ctx := signer.GetCtxWithArgs(allvars, signer.AwsDefaultVars)
+		// region of template variables takes precedence over configured region
+		configured := map[string]interface{}{}
+		if request.options.Options.AwsSignerRegion != "" {
+			configured["region"] = request.options.Options.AwsSignerRegion
+		}
+		ctx := signer.GetCtxWithArgs(allvars, configured, signer.AwsDefaultVars)
 		err = awsSigner.SignHTTP(ctx, generatedRequest.request.Request)
 		if err != nil {
 			return err
@@ -857,6 +877,41 @@ func (request *Request) handleSignature(generatedRequest *generatedRequest) erro
 	return nil
 }
 
+// applyAuthStrategy applies the auth strategy (secret) configured for the target if any
+// and returns true if the strategy requires a negotiating (ntlm) http client.
+//
+// The ntlm / negotiate handshake is performed by the http client and is not supported
+// by the unsafe (rawhttp) and pipelined clients, for those requests negotiating
+// credentials are not sent at all as they would otherwise leak as basic auth.
+func (request *Request) applyAuthStrategy(generatedRequest *generatedRequest) bool {
+	if request.options.AuthProvider == nil {
+		return false
+	}
+	var strategy authx.AuthStrategy
+	if generatedRequest.request != nil {
+		strategy = request.options.AuthProvider.LookupURL(generatedRequest.request.URL.URL)
+	} else if generatedRequest.rawRequest != nil {
+		if parsed, err := urlutil.ParseURL(generatedRequest.rawRequest.FullURL, true); err == nil {
+			strategy = request.options.AuthProvider.LookupURL(parsed.URL)
+		}
+	}
+	if strategy == nil {
+		return false
+	}
+	requiresNegotiation := authx.RequiresNegotiation(strategy)
+	if requiresNegotiation && (generatedRequest.request == nil || generatedRequest.original.Pipeline) {
+		request.negotiationWarning.Do(func() {
+			gologger.Warning().Msgf("[%s] ntlm/negotiate auth is not supported for unsafe and pipelined requests, skipping credentials", request.options.TemplateID)
+		})
+		return false
+	}
+	if generatedRequest.request == nil {
+		return false
+	}
+	strategy.Apply(generatedRequest.request.Request)
+	return requiresNegotiation
+}
+
 // setCustomHeaders sets the custom headers for generated request
 func (request *Request) setCustomHeaders(req *generatedRequest) {
 	for k, v := range request.customHeaders {
@@ -1,13 +1,20 @@
 package http
 
 import (
+	"encoding/base64"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf16"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/projectdiscovery/nuclei/v3/pkg/authprovider"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
 	"github.com/projectdiscovery/nuclei/v3/pkg/operators"
@@ -184,3 +191,128 @@ func TestDisableTE(t *testing.T) {
 	require.NotNil(t, finalEvent, "could not get event output from request")
 	require.Equal(t, 2, matchCount, "could not get correct match count")
 }
+
+// ntlmChallenge is a minimal ntlm challenge message (type 2) with unicode
+// and ntlm flags, CORP target name, a fixed server challenge and an empty target info
+var ntlmChallenge = []byte{
+	'N', 'T', 'L', 'M', 'S', 'S', 'P', 0, 2, 0, 0, 0, // signature and message type
+	8, 0, 8, 0, 48, 0, 0, 0, // target name
+	0x01, 0x02, 0, 0, // negotiate flags
+	1, 2, 3, 4, 5, 6, 7, 8, // server challenge
+	0, 0, 0, 0, 0, 0, 0, 0, // reserved
+	4, 0, 4, 0, 56, 0, 0, 0, // target info
+	'C', 0, 'O', 0, 'R', 0, 'P', 0, // target name (CORP)
+	0, 0, 0, 0, // target info (MsvAvEOL)
+}
+
+// ntlmMessageField returns an utf-16 field of the ntlm authenticate message (type 3)
+func ntlmMessageField(message []byte, offset int) string {
+	length := binary.LittleEndian.Uint16(message[offset:])
+	start := binary.LittleEndian.Uint32(message[offset+4:])
+	data := message[start : start+uint32(length)]
+	runes := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		runes = append(runes, binary.LittleEndian.Uint16(data[i:]))
+	}
+	return string(utf16.Decode(runes))
+}
+
+func TestHTTPNTLMAuth(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "testing-ntlm-auth"
+
+	var handshakes []int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if strings.HasPrefix(authorization, "Basic ") {
+			t.Errorf("credentials were sent as basic auth")
+		}
+		message, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(authorization, "NTLM "))
+		switch {
+		case len(message) < 12 || string(message[:8]) != "NTLMSSP\x00":
+			w.Header().Set("WWW-Authenticate", "NTLM")
+			w.WriteHeader(http.StatusUnauthorized)
+		case message[8] == 1: // negotiate
+			handshakes = append(handshakes, 1)
+			w.Header().Set("WWW-Authenticate", "NTLM "+base64.StdEncoding.EncodeToString(ntlmChallenge))
+			w.WriteHeader(http.StatusUnauthorized)
+		case message[8] == 3: // authenticate
+			handshakes = append(handshakes, 3)
+			if ntlmMessageField(message, 28) != "CORP" || ntlmMessageField(message, 36) != "scanner" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte("authenticated"))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer ts.Close()
+
+	secrets := fmt.Sprintf(`static:
+  - type: ntlm
+    domains:
+      - %v
+    username: scanner
+    password: password
+    domain: CORP
+`, strings.TrimPrefix(ts.URL, "http://"))
+	file := filepath.Join(t.TempDir(), "secrets.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(secrets), 0644), "could not write secrets file")
+	provider, err := authprovider.NewAuthProvider(&authprovider.AuthProviderOptions{SecretsFiles: []string{file}})
+	require.Nil(t, err, "could not create auth provider")
+
+	request := &Request{
+		ID: templateID,
+		Raw: []string{
+			`GET / HTTP/1.1
+			Host: {{Hostname}}
+			`,
+		},
+		Operators: operators.Operators{
+			Matchers: []*matchers.Matcher{{
+				Part:  "body",
+				Type:  matchers.MatcherTypeHolder{MatcherType: matchers.WordsMatcher},
+				Words: []string{"authenticated"},
+			}},
+		},
+	}
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	executerOpts.AuthProvider = provider
+	err = request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile http request")
+
+	var matched bool
+	metadata := make(output.InternalEvent)
+	previous := make(output.InternalEvent)
+	ctxArgs := contextargs.NewWithInput(ts.URL)
+	err = request.ExecuteWithResults(ctxArgs, metadata, previous, func(event *output.InternalWrappedEvent) {
+		if event.OperatorsResult != nil && event.OperatorsResult.Matched {
+			matched = true
+		}
+	})
+	require.Nil(t, err, "could not execute http request")
+	require.Equal(t, []int{1, 3}, handshakes, "could not perform ntlm handshake")
+	require.True(t, matched, "could not authenticate with ntlm")
+
+	t.Run("unsafe", func(t *testing.T) {
+		handshakes = nil
+		unsafeRequest := &Request{ID: templateID, Raw: request.Raw, Unsafe: true, Operators: request.Operators}
+		require.Nil(t, unsafeRequest.Compile(executerOpts), "could not compile http request")
+
+		var matched bool
+		err := unsafeRequest.ExecuteWithResults(contextargs.NewWithInput(ts.URL), make(output.InternalEvent), make(output.InternalEvent), func(event *output.InternalWrappedEvent) {
+			if event.OperatorsResult != nil && event.OperatorsResult.Matched {
+				matched = true
+			}
+		})
+		require.Nil(t, err, "could not execute http request")
+		require.Empty(t, handshakes, "ntlm handshake was performed for unsafe request")
+		require.False(t, matched, "unsafe request was authenticated")
+	})
+}
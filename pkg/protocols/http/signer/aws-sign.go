@@ -21,10 +21,11 @@ const defaultEmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934
 
 // AWSOptions
 type AWSOptions struct {
-	AwsID          string
-	AwsSecretToken string
-	Service        string
-	Region         string
+	AwsID           string
+	AwsSecretToken  string
+	AwsSessionToken string // optional session token of temporary (sts) credentials
+	Service         string
+	Region          string
 }
 
 // Validate Signature Arguments
@@ -48,22 +49,25 @@ type AWSSigner struct {
 
 // SignHTTP
 func (a *AWSSigner) SignHTTP(ctx context.Context, request *http.Request) error {
+	// signer is shared across requests hence options are not mutated
+	options := *a.options
 	if region, ok := ctx.Value(SignerArg("region")).(string); ok && region != "" {
-		a.options.Region = region
+		options.Region = region
 	}
 	if service, ok := ctx.Value(SignerArg("service")).(string); ok && service != "" {
-		a.options.Service = service
+		options.Service = service
 	}
-	if err := a.options.Validate(); err != nil {
+	if err := options.Validate(); err != nil {
 		return err
 	}
-	// contentHash is sha256 hash of response body
+	// contentHash is sha256 hash of request body
 	contentHash := a.getPayloadHash(request)
-	if err := a.signer.SignHTTP(ctx, *a.creds, request, contentHash, a.options.Service, a.options.Region, time.Now()); err != nil {
+	// x-amz-content-sha256 header is required by some services (ex: s3)
+	// and is set before signing to be part of the signed headers
+	request.Header.Set("x-amz-content-sha256", contentHash)
+	if err := a.signer.SignHTTP(ctx, *a.creds, request, contentHash, options.Service, options.Region, time.Now()); err != nil {
 		return errorutil.NewWithErr(err).Msgf("failed to sign http request using aws v4 signer")
 	}
-	// add x-amz-content-sha256 header to request
-	request.Header.Set("x-amz-content-sha256", contentHash)
 	return nil
 }
 
@@ -85,7 +89,7 @@ func (a *AWSSigner) getPayloadHash(request *http.Request) string {
 
 // NewAwsSigner
 func NewAwsSigner(opts *AWSOptions) (*AWSSigner, error) {
-	credcache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(opts.AwsID, opts.AwsSecretToken, ""))
+	credcache := aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(opts.AwsID, opts.AwsSecretToken, opts.AwsSessionToken))
 	awscred, err := credcache.Retrieve(context.TODO())
 	if err != nil {
 		return nil, err
@@ -132,6 +136,7 @@ var AwsDefaultVars = map[string]interface{}{
 }
 
 var AwsInternalOnlyVars = map[string]interface{}{
-	"aws-id":     struct{}{},
-	"aws-secret": struct{}{},
+	"aws-id":            struct{}{},
+	"aws-secret":        struct{}{},
+	"aws-session-token": struct{}{},
 }
@@ -0,0 +1,61 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwsSigner(t *testing.T) {
+	tests := []struct {
+		name         string
+		sessionToken string
+		body         string
+	}{
+		{"static-credentials", "", ""},
+		{"session-token", "test-session-token", ""},
+		{"body", "", `{"Action":"GetCallerIdentity"}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			awsSigner, err := NewAwsSigner(&AWSOptions{AwsID: "AKIDEXAMPLE", AwsSecretToken: "secret", AwsSessionToken: test.sessionToken})
+			require.Nil(t, err, "could not create aws signer")
+
+			var body io.Reader
+			if test.body != "" {
+				body = bytes.NewReader([]byte(test.body))
+			}
+			req, err := http.NewRequest(http.MethodPost, "https://sts.amazonaws.com/", body)
+			require.Nil(t, err, "could not create request")
+
+			ctx := GetCtxWithArgs(map[string]interface{}{"region": "eu-west-1"}, AwsDefaultVars)
+			require.Nil(t, awsSigner.SignHTTP(ctx, req), "could not sign request")
+
+			expectedHash := defaultEmptyPayloadHash
+			if test.body != "" {
+				hash := sha256.Sum256([]byte(test.body))
+				expectedHash = hex.EncodeToString(hash[:])
+			}
+			require.Equal(t, expectedHash, req.Header.Get("x-amz-content-sha256"), "could not get correct payload hash")
+
+			authorization := req.Header.Get("Authorization")
+			require.Contains(t, authorization, "Credential=AKIDEXAMPLE/", "could not get correct credential")
+			require.Contains(t, authorization, "/eu-west-1/sts/aws4_request", "could not get correct scope")
+			require.Contains(t, authorization, "x-amz-content-sha256", "payload hash is not signed")
+
+			if test.sessionToken != "" {
+				require.Equal(t, test.sessionToken, req.Header.Get("X-Amz-Security-Token"), "could not get session token")
+				require.Contains(t, authorization, "x-amz-security-token", "session token is not signed")
+			} else {
+				require.Empty(t, req.Header.Get("X-Amz-Security-Token"), "got session token for static credentials")
+				require.False(t, strings.Contains(authorization, "x-amz-security-token"), "got signed session token for static credentials")
+			}
+		})
+	}
+}
@@ -35,8 +35,14 @@ func (c *Configuration) Hash() string {
 	return hash
 }
 
-// Get creates or gets a client for the protocol based on custom configuration
+// Get creates or gets a client for the protocol based on custom configuration.
+// aws signers without credentials use the aws signer credentials of options if any
 func Get(options *types.Options, configuration *Configuration) (signer.Signer, error) {
+	if awsOptions, ok := configuration.SignerArgs.(*signer.AWSOptions); ok && awsOptions.AwsID == "" && awsOptions.AwsSecretToken == "" {
+		awsOptions.AwsID = options.AwsSignerAccessKey
+		awsOptions.AwsSecretToken = options.AwsSignerSecretKey
+		awsOptions.AwsSessionToken = options.AwsSignerSessionToken
+	}
 	hash := configuration.Hash()
 	poolMutex.RLock()
 	if client, ok := clientPool[hash]; ok {
@@ -0,0 +1,27 @@
+package signerpool
+
+import (
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/signer"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAwsSignerCredentials(t *testing.T) {
+	require.Nil(t, Init(&types.Options{}))
+	options := &types.Options{AwsSignerAccessKey: "AKIDEXAMPLE", AwsSignerSecretKey: "secret", AwsSignerSessionToken: "token"}
+
+	awsOptions := &signer.AWSOptions{}
+	_, err := Get(options, &Configuration{SignerArgs: awsOptions})
+	require.Nil(t, err, "could not get aws signer")
+	require.Equal(t, "AKIDEXAMPLE", awsOptions.AwsID, "could not use configured access key")
+	require.Equal(t, "secret", awsOptions.AwsSecretToken, "could not use configured secret key")
+	require.Equal(t, "token", awsOptions.AwsSessionToken, "could not use configured session token")
+
+	templateOptions := &signer.AWSOptions{AwsID: "AKIDTEMPLATE", AwsSecretToken: "template-secret"}
+	_, err = Get(options, &Configuration{SignerArgs: templateOptions})
+	require.Nil(t, err, "could not get aws signer")
+	require.Equal(t, "AKIDTEMPLATE", templateOptions.AwsID, "template credentials were overridden")
+	require.Empty(t, templateOptions.AwsSessionToken, "template credentials were overridden")
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/helpers/eventcreator"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/utils/vardump"
 	protocolutils "github.com/projectdiscovery/nuclei/v3/pkg/protocols/utils"
 	templateTypes "github.com/projectdiscovery/nuclei/v3/pkg/templates/types"
@@ -245,6 +246,10 @@ func (request *Request) GetID() string {
 
 // ExecuteWithResults executes the protocol requests and returns results instead of writing them.
 func (request *Request) ExecuteWithResults(target *contextargs.Context, dynamicValues, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
+	// not all javascript libraries use the (proxied) shared dialer
+	if err := protocolstate.ValidateProxyBypass(request.Type().String(), false); err != nil {
+		return err
+	}
 
 	input := target.Clone()
 	// use network port updates input with new port requested in template file
@@ -610,6 +615,17 @@ func (request *Request) Type() templateTypes.ProtocolType {
 	return templateTypes.JavascriptProtocol
 }
 
+// Ports returns the list of ports the request is sent to
+func (request *Request) Ports() []string {
+	var ports []string
+	for _, port := range strings.Split(request.getPort(), ",") {
+		if port = strings.TrimSpace(port); port != "" {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
 func (request *Request) getPort() string {
 	for k, v := range request.Args {
 		if strings.EqualFold(k, "Port") {
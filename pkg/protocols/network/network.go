@@ -151,6 +151,11 @@ func (request *Request) GetID() string {
 	return request.ID
 }
 
+// Ports returns the list of ports the request is sent to (available after compilation)
+func (request *Request) Ports() []string {
+	return request.ports
+}
+
 // Compile compiles the protocol request for further execution.
 func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 	var shouldUseTLS bool
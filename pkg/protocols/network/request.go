@@ -204,10 +204,12 @@ func (request *Request) executeRequestWithPayloads(variables map[string]interfac
 		hostname = host
 	}
 
-	if shouldUseTLS {
-		conn, err = request.dialer.DialTLS(context.Background(), "tcp", actualAddress)
-	} else {
-		conn, err = request.dialer.Dial(context.Background(), "tcp", actualAddress)
+	if err = protocolstate.ValidateProxyBypass(request.Type().String(), protocolstate.IsDialerProxied()); err == nil {
+		if shouldUseTLS {
+			conn, err = protocolstate.DialTLS(context.Background(), request.dialer, "tcp", actualAddress, nil)
+		} else {
+			conn, err = request.dialer.Dial(context.Background(), "tcp", actualAddress)
+		}
 	}
 	if err != nil {
 		request.options.Output.Request(request.options.TemplatePath, address, request.Type().String(), err)
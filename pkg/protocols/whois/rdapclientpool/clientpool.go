@@ -2,8 +2,11 @@ package rdapclientpool
 
 import (
 	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/rdap"
+	"github.com/projectdiscovery/rdap/bootstrap"
 )
 
 var normalClient *rdap.Client
@@ -16,6 +19,15 @@ func Init(options *types.Options) error {
 	}
 
 	normalClient = &rdap.Client{}
+	if protocolstate.IsProxyConfigured() {
+		// send rdap queries through the proxies of http traffic
+		client, err := httpclientpool.Get(options, &httpclientpool.Configuration{RedirectFlow: httpclientpool.FollowAllRedirect, MaxRedirects: 10})
+		if err != nil {
+			return err
+		}
+		normalClient.HTTP = client.HTTPClient
+		normalClient.Bootstrap = &bootstrap.Client{HTTP: client.HTTPClient}
+	}
 	if options.Verbose || options.Debug || options.DebugRequests || options.DebugResponse {
 		normalClient.Verbose = func(text string) {
 			gologger.Debug().Msgf("rdap: %s", text)
@@ -0,0 +1,194 @@
+// Package suppression implements false-positive suppression rules which
+// drop matching results before they are written to output or reported.
+package suppression
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// dateLayout is the layout of date only rule expiry
+const dateLayout = "2006-01-02"
+
+// expiryLayouts are the supported layouts of rule expiry
+var expiryLayouts = []string{time.RFC3339, dateLayout}
+
+// Rule is a suppression rule for known false positives
+type Rule struct {
+	// TemplateID is the id of the template (wildcards supported)
+	TemplateID string `yaml:"template-id"`
+	// Host is the host pattern of the result (wildcards supported)
+	Host string `yaml:"host,omitempty"`
+	// URL is the url pattern of the result (wildcards supported)
+	URL string `yaml:"url,omitempty"`
+	// MatcherName optionally restricts the rule to a matcher of the template
+	MatcherName string `yaml:"matcher-name,omitempty"`
+	// Expires is the date (2006-01-02, inclusive) or time (RFC3339) after which the rule is ignored
+	Expires string `yaml:"expires,omitempty"`
+	// Reason is the reason of the suppression
+	Reason string `yaml:"reason,omitempty"`
+
+	templateID *regexp.Regexp
+	host       *regexp.Regexp
+	url        *regexp.Regexp
+	expiresAt  time.Time
+}
+
+// compile validates and compiles the rule patterns
+func (r *Rule) compile() error {
+	if r.TemplateID == "" {
+		return errorutil.New("template-id cannot be empty")
+	}
+	if r.Host == "" && r.URL == "" {
+		return errorutil.New("host or url cannot be empty in rule of %v", r.TemplateID)
+	}
+	r.templateID = globToRegex(r.TemplateID)
+	if r.Host != "" {
+		r.host = globToRegex(r.Host)
+	}
+	if r.URL != "" {
+		r.url = globToRegex(r.URL)
+	}
+	if r.Expires != "" {
+		var err error
+		for _, layout := range expiryLayouts {
+			if r.expiresAt, err = time.Parse(layout, r.Expires); err == nil {
+				if layout == dateLayout {
+					// date only expiry suppresses till the end of the day
+					r.expiresAt = r.expiresAt.AddDate(0, 0, 1)
+				}
+				break
+			}
+		}
+		if err != nil {
+			return errorutil.New("invalid expiry %v in rule of %v", r.Expires, r.TemplateID)
+		}
+	}
+	return nil
+}
+
+// Expired returns true if the rule has expired
+func (r *Rule) Expired() bool {
+	return !r.expiresAt.IsZero() && !time.Now().Before(r.expiresAt)
+}
+
+// Match returns true if the result event matches the rule
+func (r *Rule) Match(event *output.ResultEvent) bool {
+	if r.Expired() || !r.templateID.MatchString(event.TemplateID) {
+		return false
+	}
+	if r.MatcherName != "" && !strings.EqualFold(r.MatcherName, event.MatcherName) {
+		return false
+	}
+	if r.host != nil && !matchAny(r.host, event.Host, hostname(event.Host)) {
+		return false
+	}
+	if r.url != nil && !matchAny(r.url, event.Matched, event.URL) {
+		return false
+	}
+	return true
+}
+
+// Suppressor drops results matching suppression rules
+type Suppressor struct {
+	rules      []*Rule
+	suppressed atomic.Uint64
+}
+
+// New creates a new suppressor from the rules of given files
+func New(files ...string) (*Suppressor, error) {
+	s := &Suppressor{}
+	for _, file := range files {
+		rules, err := ReadRulesFromFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if rule.Expired() {
+				gologger.Warning().Msgf("Ignoring expired suppression rule of %v (expired at %v)\n", rule.TemplateID, rule.Expires)
+				continue
+			}
+			s.rules = append(s.rules, rule)
+		}
+	}
+	return s, nil
+}
+
+// ReadRulesFromFile reads and compiles the suppression rules of a yaml file
+func ReadRulesFromFile(file string) ([]*Rule, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not read suppression file %v", file)
+	}
+	var rules []*Rule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not unmarshal suppression file %v", file)
+	}
+	for _, rule := range rules {
+		if err := rule.compile(); err != nil {
+			return nil, errorutil.NewWithErr(err).Msgf("invalid rule in suppression file %v", file)
+		}
+	}
+	return rules, nil
+}
+
+// Suppress returns true if the result event matches any rule
+// and must be dropped. Suppressed events are counted.
+func (s *Suppressor) Suppress(event *output.ResultEvent) bool {
+	if s == nil {
+		return false
+	}
+	for _, rule := range s.rules {
+		if rule.Match(event) {
+			s.suppressed.Add(1)
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of suppressed results
+func (s *Suppressor) Count() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.suppressed.Load()
+}
+
+// globToRegex compiles a case-insensitive pattern with * wildcards
+func globToRegex(pattern string) *regexp.Regexp {
+	quoted := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*")
+	return regexp.MustCompile("(?i)^" + quoted + "$")
+}
+
+// hostname returns the hostname of an input (url or host:port)
+func hostname(input string) string {
+	if strings.Contains(input, "://") {
+		if u, err := url.Parse(input); err == nil {
+			return u.Hostname()
+		}
+	}
+	if host, _, err := net.SplitHostPort(input); err == nil {
+		return host
+	}
+	return input
+}
+
+func matchAny(re *regexp.Regexp, values ...string) bool {
+	for _, value := range values {
+		if value != "" && re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package suppression
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppressor(t *testing.T) {
+	rules := `- template-id: tech-detect
+  host: "*.example.com"
+  matcher-name: nginx
+- template-id: CVE-2021-*
+  url: https://scanme.sh/admin*
+- template-id: expired-rule
+  host: scanme.sh
+  expires: 2020-01-01
+- template-id: expires-today
+  host: scanme.sh
+  expires: ` + time.Now().UTC().Format("2006-01-02") + `
+`
+	file := filepath.Join(t.TempDir(), "suppression.yaml")
+	require.Nil(t, os.WriteFile(file, []byte(rules), 0644), "could not write suppression file")
+
+	suppressor, err := New(file)
+	require.Nil(t, err, "could not create suppressor")
+
+	tests := []struct {
+		event    *output.ResultEvent
+		expected bool
+	}{
+		{&output.ResultEvent{TemplateID: "tech-detect", Host: "https://www.example.com:8443", MatcherName: "nginx"}, true},
+		{&output.ResultEvent{TemplateID: "tech-detect", Host: "www.example.com", MatcherName: "apache"}, false},
+		{&output.ResultEvent{TemplateID: "CVE-2021-1234", Host: "scanme.sh", Matched: "https://scanme.sh/admin/login"}, true},
+		{&output.ResultEvent{TemplateID: "CVE-2021-1234", Host: "scanme.sh", Matched: "https://scanme.sh/"}, false},
+		{&output.ResultEvent{TemplateID: "expired-rule", Host: "scanme.sh"}, false},
+		{&output.ResultEvent{TemplateID: "expires-today", Host: "scanme.sh"}, true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, suppressor.Suppress(test.event), "could not get correct suppression for %v", test.event.TemplateID)
+	}
+	require.Equal(t, uint64(3), suppressor.Count(), "could not get correct suppressed count")
+}
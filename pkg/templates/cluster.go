@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
@@ -150,6 +151,23 @@ func ClusterTemplates(templatesList []*Template, options protocols.ExecutorOptio
 				req.Options().TemplateID = clusterID
 			}
 			executerOpts.TemplateID = clusterID
+			// requests of the cluster are shared by all clustered templates
+			// and hence recorded for each of them in execution statistics
+			if options.ExecutionStats != nil {
+				templateIDs := make([]string, 0, len(cluster))
+				for _, template := range cluster {
+					templateIDs = append(templateIDs, template.ID)
+				}
+				for _, req := range cluster[0].RequestsDNS {
+					req.Options().Output = options.ExecutionStats.Writer(options.Output, templateIDs...)
+				}
+				for _, req := range cluster[0].RequestsHTTP {
+					req.Options().Output = options.ExecutionStats.Writer(options.Output, templateIDs...)
+				}
+				for _, req := range cluster[0].RequestsSSL {
+					req.Options().Output = options.ExecutionStats.Writer(options.Output, templateIDs...)
+				}
+			}
 			finalTemplatesList = append(finalTemplatesList, &Template{
 				ID:            clusterID,
 				RequestsDNS:   cluster[0].RequestsDNS,
@@ -242,6 +260,17 @@ func (e *ClusterExecuter) Requests() int {
 // Execute executes the protocol group and returns true or false if results were found.
 func (e *ClusterExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 	var results bool
+	// execution is recorded for each clustered template
+	matchedTemplates := mapsutil.NewSyncLockMap[string, struct{}]()
+	if e.options.ExecutionStats != nil {
+		start := time.Now()
+		defer func() {
+			duration := time.Since(start)
+			for _, operator := range e.operators {
+				e.options.ExecutionStats.RecordExecution(operator.templateID, ctx.Input.MetaInput.Input, duration, matchedTemplates.Has(operator.templateID))
+			}
+		}()
+	}
 
 	inputItem := ctx.Input.Clone()
 	if e.options.InputHelper != nil && ctx.Input.MetaInput.Input != "" {
@@ -268,8 +297,9 @@ func (e *ClusterExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 				event.OperatorsResult = result
 				event.Results = e.requests.MakeResultEvent(event)
 				results = true
+				_ = matchedTemplates.Set(operator.templateID, struct{}{})
 
-				_ = writer.WriteResult(event, e.options.Output, e.options.Progress, e.options.IssuesClient)
+				_ = writer.WriteResult(event, e.options.Output, e.options.Progress, e.options.IssuesClient, e.options.Suppressor)
 			}
 		}
 	})
@@ -337,6 +337,10 @@ func parseTemplate(data []byte, options protocols.ExecutorOptions) (*Template, e
 	options.TemplateID = template.ID
 	options.TemplateInfo = template.Info
 	options.StopAtFirstMatch = template.StopAtFirstMatch
+	// record requests of the template for execution statistics
+	if options.ExecutionStats != nil && options.Output != nil {
+		options.Output = options.ExecutionStats.Writer(options.Output, template.ID)
+	}
 
 	if template.Variables.Len() > 0 {
 		options.Variables = template.Variables
@@ -121,6 +121,25 @@ func (t *TemplateSigner) sign(data []byte) (string, error) {
 	return fmt.Sprintf(SignatureFmt, signatureData.Bytes(), t.GetUserFragment()), nil
 }
 
+// SignData signs the given data with the signer private key and returns
+// the asn1 encoded ecdsa signature of its sha256 digest
+func (t *TemplateSigner) SignData(data []byte) ([]byte, error) {
+	dataHash := sha256.Sum256(data)
+	return ecdsa.SignASN1(rand.Reader, t.handler.ecdsaKey, dataHash[:])
+}
+
+// VerifyData verifies the asn1 encoded ecdsa signature of given data
+// created using SignData
+func (t *TemplateSigner) VerifyData(data, signature []byte) bool {
+	dataHash := sha256.Sum256(data)
+	return ecdsa.VerifyASN1(t.handler.ecdsaPubKey, dataHash[:], signature)
+}
+
+// Certificate returns the pem encoded user certificate of the signer
+func (t *TemplateSigner) Certificate() []byte {
+	return t.handler.UserCert
+}
+
 // Verify verifies the given template with the template signer
 func (t *TemplateSigner) Verify(data []byte, tmpl SignableTemplate) (bool, error) {
 	digestData := ReDigest.Find(data)
@@ -4,14 +4,17 @@ package templates
 import (
 	"encoding/json"
 	"io"
+	"net"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	validate "github.com/go-playground/validator/v10"
+	inputs "github.com/projectdiscovery/nuclei/v3/pkg/input"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/code"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/variables"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/dns"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/file"
@@ -26,6 +29,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/workflows"
 	errorutil "github.com/projectdiscovery/utils/errors"
 	fileutil "github.com/projectdiscovery/utils/file"
+	sliceutil "github.com/projectdiscovery/utils/slice"
 	"go.uber.org/multierr"
 	"gopkg.in/yaml.v2"
 )
@@ -196,6 +200,43 @@ func (template *Template) HasCodeProtocol() bool {
 	return len(template.RequestsCode) > 0
 }
 
+// MatchesServiceHints returns true if the template should be executed on given input.
+// Network and javascript templates are only routed to inputs carrying service hints
+// if they target the input port, a port of one of the hinted services or are tagged
+// with one of the hinted services.
+func (template *Template) MatchesServiceHints(input *contextargs.MetaInput) bool {
+	if !input.HasServiceHints() {
+		return true
+	}
+	var ports []string
+	switch template.Type() {
+	case types.NetworkProtocol:
+		for _, request := range template.RequestsNetwork {
+			ports = append(ports, request.Ports()...)
+		}
+	case types.JavascriptProtocol:
+		for _, request := range template.RequestsJavascript {
+			ports = append(ports, request.Ports()...)
+		}
+	default:
+		return true
+	}
+	if _, port, err := net.SplitHostPort(input.Input); err == nil && sliceutil.Contains(ports, port) {
+		return true
+	}
+	for _, port := range ports {
+		if service := inputs.ServiceForPort(port); service != "" && sliceutil.Contains(input.Services, service) {
+			return true
+		}
+	}
+	for _, tag := range template.Info.Tags.ToSlice() {
+		if sliceutil.Contains(input.Services, strings.ToLower(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
 // validateAllRequestIDs check if that protocol already has given id if not
 // then is is manually set to proto_index
 func (template *Template) validateAllRequestIDs() {
@@ -5,6 +5,12 @@ import (
 	"os"
 	"testing"
 
+	"github.com/projectdiscovery/nuclei/v3/pkg/input"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/javascript"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v2"
 )
@@ -34,3 +40,42 @@ func TestTemplateStruct(t *testing.T) {
 	err = yaml.Unmarshal(yamlBin, &yamlTemplate)
 	require.Nil(t, err, "failed to unmarshal yaml template")
 }
+
+func TestMatchesServiceHints(t *testing.T) {
+	newJavascriptTemplate := func(port string, tags string) *Template {
+		return &Template{
+			Info:               model.Info{Tags: stringslice.New(tags)},
+			RequestsJavascript: []*javascript.Request{{Args: map[string]interface{}{"Port": port}}},
+		}
+	}
+	sshTemplate := newJavascriptTemplate("22", "ssh,network")
+	mysqlTemplate := newJavascriptTemplate("3306", "mysql")
+	untaggedSSHTemplate := newJavascriptTemplate("22", "")
+	httpTemplate := &Template{RequestsHTTP: []*http.Request{{}}}
+
+	tests := []struct {
+		name     string
+		target   string
+		template *Template
+		expected bool
+	}{
+		{"no-hints", "10.0.0.1:3306", sshTemplate, true},
+		{"http-template", "10.0.0.1:22,2222", httpTemplate, true},
+		{"matching-port", "10.0.0.1:22,2222", sshTemplate, true},
+		{"matching-port-service", "10.0.0.1:22,2222", untaggedSSHTemplate, true},
+		{"non-matching-port", "10.0.0.1:22,2222", mysqlTemplate, false},
+		{"unknown-ports", "10.0.0.1:8022,9022", sshTemplate, false},
+		{"matching-tag", "10.0.0.1:2222,ssh", sshTemplate, true},
+		{"non-matching-tag", "10.0.0.1:2222,ssh", mysqlTemplate, false},
+		{"service-only", "10.0.0.1,mysql", mysqlTemplate, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targets, services := input.ParseServiceHints(test.target)
+			for _, target := range targets {
+				metaInput := &contextargs.MetaInput{Input: target, Services: services}
+				require.Equal(t, test.expected, test.template.MatchesServiceHints(metaInput), "invalid result for %v", target)
+			}
+		})
+	}
+}
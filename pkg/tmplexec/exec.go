@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/operators/common/dsl"
@@ -99,6 +100,12 @@ func (e *TemplateExecuter) Requests() int {
 // Execute executes the protocol group and returns true or false if results were found.
 func (e *TemplateExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 	results := &atomic.Bool{}
+	if e.options.ExecutionStats != nil {
+		start := time.Now()
+		defer func() {
+			e.options.ExecutionStats.RecordExecution(e.options.TemplateID, ctx.Input.MetaInput.Input, time.Since(start), results.Load())
+		}()
+	}
 	defer func() {
 		// it is essential to remove template context of `Scan i.e template x input pair`
 		// since it is of no use after scan is completed (regardless of success or failure)
@@ -142,9 +149,11 @@ func (e *TemplateExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 		if !event.HasOperatorResult() && !event.UsesInteractsh {
 			lastMatcherEvent = event
 		} else {
-			if writer.WriteResult(event, e.options.Output, e.options.Progress, e.options.IssuesClient) {
+			if writer.WriteResult(event, e.options.Output, e.options.Progress, e.options.IssuesClient, e.options.Suppressor) {
 				results.CompareAndSwap(false, true)
-			} else {
+			} else if !event.HasResults() {
+				// events with results which were not written are suppressed
+				// and must not be written as failures either
 				lastMatcherEvent = event
 			}
 		}
@@ -9,4 +9,7 @@ var (
 	ProxyURL string
 	// ProxySocksURL is the URL for the proxy socks server
 	ProxySocksURL string
+	// NetworkProxyURL is the URL for the proxy server used by non-http
+	// (dns/network/headless) traffic
+	NetworkProxyURL string
 )
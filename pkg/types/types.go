@@ -44,8 +44,14 @@ type Options struct {
 	ExcludedTemplates goflags.StringSlice
 	// ExcludeMatchers is a list of matchers to exclude processing
 	ExcludeMatchers goflags.StringSlice
+	// SuppressionFile is the list of files containing false-positive suppression rules
+	SuppressionFile goflags.StringSlice
 	// CustomHeaders is the list of custom global headers to send with each request.
 	CustomHeaders goflags.StringSlice
+	// UserAgentProfiles is the list of browser profile presets or files to rotate user-agent (and matching headers) from
+	UserAgentProfiles goflags.StringSlice
+	// UserAgentRotation is the rotation mode of browser profiles (request or host)
+	UserAgentRotation string
 	// Vars is the list of custom global vars
 	Vars goflags.RuntimeMap
 	// Severities filters templates based on their severity and only run the matching ones.
@@ -90,6 +96,12 @@ type Options struct {
 	ListDslSignatures bool
 	// List of HTTP(s)/SOCKS5 proxy to use (comma separated or file input)
 	Proxy goflags.StringSlice
+	// List of HTTP(s)/SOCKS5 proxy to use for http traffic only (comma separated or file input)
+	ProxyHTTP goflags.StringSlice
+	// List of SOCKS5 proxy to use for non-http traffic (comma separated or file input)
+	ProxyNetwork goflags.StringSlice
+	// ProxyFailClosed denies requests which cannot be sent through proxy instead of sending them directly
+	ProxyFailClosed bool
 	// TemplatesDirectory is the directory to use for storing templates
 	NewTemplatesDirectory string
 	// TraceLogFile specifies a file to write with the trace of all requests
@@ -160,6 +172,8 @@ type Options struct {
 	ForceAttemptHTTP2 bool
 	// StatsJSON writes stats output in JSON format
 	StatsJSON bool
+	// TemplateStatsExport is the file to export per-template execution statistics to (json)
+	TemplateStatsExport string
 	// Headless specifies whether to allow headless mode templates
 	Headless bool
 	// ShowBrowser specifies whether the show the browser in headless mode
@@ -216,6 +230,10 @@ type Options struct {
 	JSONExport string
 	// JSONLExport is the file to export JSONL output format to
 	JSONLExport string
+	// EvidenceBundle is the file to export evidence bundle (tar.gz) to
+	EvidenceBundle string
+	// EvidenceBundleSign signs the evidence bundle manifest using nuclei user certificate and private key
+	EvidenceBundleSign bool
 	// EnableProgressBar enables progress bar
 	EnableProgressBar bool
 	// TemplateDisplay displays the template contents
@@ -252,6 +270,8 @@ type Options struct {
 	ClientKeyFile string
 	// ClientCAFile client certificate authority file (PEM-encoded) used for authenticating against scanned hosts
 	ClientCAFile string
+	// SecretsFile is the list of files containing secrets (auth data) for authenticated scans
+	SecretsFile goflags.StringSlice
 	// Deprecated: Use ZTLS library
 	ZTLS bool
 	// AllowLocalFileAccess allows local file access from templates payloads
@@ -334,6 +354,14 @@ type Options struct {
 	AwsRegion string
 	// AwsTemplateDisableDownload disables downloading templates from AWS S3 buckets
 	AwsTemplateDisableDownload bool
+	// AwsSignerAccessKey is the aws access key used to sign requests of templates with aws signature
+	AwsSignerAccessKey string
+	// AwsSignerSecretKey is the aws secret key used to sign requests of templates with aws signature
+	AwsSignerSecretKey string
+	// AwsSignerSessionToken is the aws session token of temporary credentials used to sign requests
+	AwsSignerSessionToken string
+	// AwsSignerRegion is the aws region used to sign requests if not defined by the template
+	AwsSignerRegion string
 	// AzureContainerName for downloading templates from Azure Blob Storage. Example: templates
 	AzureContainerName string
 	// AzureTenantID for downloading templates from Azure Blob Storage. Example: 00000000-0000-0000-0000-000000000000